@@ -80,3 +80,75 @@ func alignTextVertical(str string, pos Position, height int, _ *ansi.Style) stri
 	}
 	return str
 }
+
+// AlignDecimal pads strings so that the numbers in them line up on the given
+// decimal separator, like '.' or ','. Numbers without decimals line up as if
+// their separator followed their last digit. Thousands separators are part of
+// the numbers, and the text following them, like unit suffixes, lines up too.
+// Strings without numbers line up with the integer parts. The returned
+// strings all have the same width.
+//
+// Example:
+//
+//	lipgloss.AlignDecimal('.', "1,024.5 MB", "12 KB", "3.25 GB")
+//	// "1,024.5  MB"
+//	// "   12    KB"
+//	// "    3.25 GB"
+func AlignDecimal(sep rune, strs ...string) []string {
+	parts := make([][3]int, len(strs))
+	var widest [3]int
+	for i, s := range strs {
+		parts[i] = decimalParts(s, sep)
+		for k := range widest {
+			widest[k] = max(widest[k], parts[i][k])
+		}
+	}
+
+	aligned := make([]string, len(strs))
+	for i, s := range strs {
+		integer, fraction, suffix := parts[i][0], parts[i][1], parts[i][2]
+		aligned[i] = strings.Repeat(" ", widest[0]-integer) +
+			ansi.Cut(s, 0, integer+fraction) +
+			strings.Repeat(" ", widest[1]-fraction) +
+			ansi.Cut(s, integer+fraction, integer+fraction+suffix) +
+			strings.Repeat(" ", widest[2]-suffix)
+	}
+	return aligned
+}
+
+// decimalParts returns the widths of the part of a string up to the decimal
+// separator of its first number, of the separator and the decimals, and of
+// the rest of the string.
+func decimalParts(s string, sep rune) [3]int {
+	runes := []rune(ansi.Strip(s))
+	width := func(from, to int) int {
+		return ansi.StringWidth(string(runes[from:to]))
+	}
+	isDigit := func(i int) bool {
+		return i < len(runes) && runes[i] >= '0' && runes[i] <= '9'
+	}
+	isGroup := func(i int) bool {
+		return runes[i] != sep && strings.ContainsRune(",._'", runes[i]) && isDigit(i+1)
+	}
+
+	start := 0
+	for start < len(runes) && !isDigit(start) {
+		start++
+	}
+	if start == len(runes) {
+		return [3]int{width(0, len(runes)), 0, 0}
+	}
+
+	integer := start
+	for integer < len(runes) && (isDigit(integer) || isGroup(integer)) {
+		integer++
+	}
+	fraction := integer
+	if fraction < len(runes) && runes[fraction] == sep {
+		fraction++
+		for isDigit(fraction) {
+			fraction++
+		}
+	}
+	return [3]int{width(0, integer), width(integer, fraction), width(fraction, len(runes))}
+}
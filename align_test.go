@@ -39,3 +39,63 @@ func TestAlignTextVertical(t *testing.T) {
 		}
 	}
 }
+
+func TestAlignDecimal(t *testing.T) {
+	tests := []struct {
+		name string
+		sep  rune
+		strs []string
+		want []string
+	}{
+		{
+			name: "decimals",
+			sep:  '.',
+			strs: []string{"1.5", "10", "0.125"},
+			want: []string{" 1.5  ", "10    ", " 0.125"},
+		},
+		{
+			name: "thousands and units",
+			sep:  '.',
+			strs: []string{"1,024.5 MB", "12 KB", "3.25 GB"},
+			want: []string{"1,024.5  MB", "   12    KB", "    3.25 GB"},
+		},
+		{
+			name: "comma separator",
+			sep:  ',',
+			strs: []string{"1.234,5 €", "-7 €"},
+			want: []string{"1.234,5 €", "   -7   €"},
+		},
+		{
+			name: "not numbers",
+			sep:  '.',
+			strs: []string{"n/a", "12.5%", ""},
+			want: []string{"n/a   ", " 12.5%", "      "},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := AlignDecimal(test.sep, test.strs...)
+			for i := range test.want {
+				if got[i] != test.want[i] {
+					t.Errorf("AlignDecimal(%q, %q)[%d] = %q, want %q", test.sep, test.strs, i, got[i], test.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestStyleAlignDecimal(t *testing.T) {
+	s := NewStyle().AlignDecimal('.').AlignHorizontal(Right).Width(10)
+	got := s.Render("3.14\n42\n-0.5")
+	want := "      3.14\n     42   \n     -0.5 "
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+	if sep := s.GetAlignDecimal(); sep != '.' {
+		t.Errorf("GetAlignDecimal() = %q, want '.'", sep)
+	}
+	if sep := s.UnsetAlignDecimal().GetAlignDecimal(); sep != 0 {
+		t.Errorf("GetAlignDecimal() after unset = %q, want 0", sep)
+	}
+}
@@ -0,0 +1,304 @@
+package lipgloss
+
+import (
+	"image"
+	"math"
+)
+
+// Placement is the side of another layer a [Layer] is anchored to. See
+// [Layer.AnchorTo].
+type Placement int
+
+// Placement options.
+const (
+	Above Placement = iota
+	Below
+	LeftOf
+	RightOf
+)
+
+// opposite returns the placement on the other side of the target.
+func (p Placement) opposite() Placement {
+	switch p {
+	case Above:
+		return Below
+	case Below:
+		return Above
+	case LeftOf:
+		return RightOf
+	default:
+		return LeftOf
+	}
+}
+
+// anchor describes how a [Layer] is positioned relative to its parent or to
+// another layer.
+type anchor struct {
+	// The ID of the layer to anchor to. If empty, the layer is placed inside
+	// its parent.
+	target    string
+	placement Placement
+	align     Position
+
+	// The position inside the parent.
+	hPos, vPos Position
+}
+
+// Place positions the Layer inside its parent, like [Place] does for strings.
+// For instance, Place(Center, Center) centers the Layer in its parent and
+// Place(Right, Bottom) puts it in the bottom-right corner.
+//
+// The X and Y coordinates of the Layer are added as offsets, so use negative
+// values to inset it from the right or bottom edges. Placed layers are not
+// affected by the scroll offset of their parent.
+//
+// Top-level layers of a [Compositor] are placed in the bounds of the other,
+// non-anchored, layers.
+func (l *Layer) Place(hPos, vPos Position) *Layer {
+	l.anchor = &anchor{hPos: hPos, vPos: vPos}
+	l.changed()
+	return l
+}
+
+// AnchorTo positions the Layer next to the layer with the given ID. The
+// placement determines the side of the target layer the Layer is put on and
+// align its alignment along that side. For instance, AnchorTo("menu",
+// RightOf, Top) puts the Layer to the right of "menu", aligned with its top
+// edge.
+//
+// The X and Y coordinates of the Layer are added as offsets. Anchors are
+// resolved by the [Compositor]; if the target doesn't exist, is hidden, or
+// anchors form a cycle, the Layer is positioned as usual.
+//
+// Layers anchored to other layers don't count towards the size of their
+// parent.
+func (l *Layer) AnchorTo(id string, placement Placement, align Position) *Layer {
+	l.anchor = &anchor{target: id, placement: placement, align: align}
+	l.changed()
+	return l
+}
+
+// Flip sets whether a Layer anchored with [Layer.AnchorTo] moves to the
+// opposite side of its target when there isn't enough room on the requested
+// side. The available room is determined by the clip rectangles of the
+// Layer's ancestors and the bounds of the non-anchored layers.
+func (l *Layer) Flip(flip bool) *Layer {
+	l.flip = flip
+	l.changed()
+	return l
+}
+
+// GetFlip returns whether the Layer flips to the opposite side of its anchor
+// when there isn't enough room.
+func (l *Layer) GetFlip() bool {
+	return l.flip
+}
+
+// UnsetAnchor removes the placement set with [Layer.Place] or
+// [Layer.AnchorTo], so that the Layer is positioned with its X and Y
+// coordinates only.
+func (l *Layer) UnsetAnchor() *Layer {
+	l.anchor = nil
+	l.changed()
+	return l
+}
+
+// placed returns whether the Layer is placed inside its parent.
+func (l *Layer) placed() bool {
+	return l.anchor != nil && l.anchor.target == ""
+}
+
+// anchored returns whether the Layer is anchored to another layer.
+func (l *Layer) anchored() bool {
+	return l.anchor != nil && l.anchor.target != ""
+}
+
+// placeIn returns the offset of a box of the given size placed inside an area
+// of the given size.
+func placeIn(area, size int, pos Position) int {
+	return int(math.Round(float64(area-size) * pos.value()))
+}
+
+// resolveOrigins calculates the absolute origin of every layer, resolving
+// anchors along the way.
+func (c *Compositor) resolveOrigins() {
+	c.origins = make(map[*Layer]image.Point)
+	c.resolving = make(map[*Layer]bool)
+	c.scene = image.Rectangle{}
+	c.sceneBounds(c.root)
+}
+
+// sceneBounds calculates the bounds of the layers that are neither anchored
+// nor placed, which is the area anchored layers are placed in and flipped
+// within.
+func (c *Compositor) sceneBounds(layer *Layer) {
+	if layer.hidden || layer.anchor != nil {
+		return
+	}
+	width, height := layer.contentSize()
+	origin := c.origin(layer)
+	c.scene = c.scene.Union(image.Rectangle{
+		Min: origin,
+		Max: origin.Add(image.Pt(width, height)),
+	})
+	for _, child := range layer.layers {
+		c.sceneBounds(child)
+	}
+}
+
+// origin returns the absolute origin of a layer.
+func (c *Compositor) origin(layer *Layer) image.Point {
+	if origin, ok := c.origins[layer]; ok {
+		return origin
+	}
+	if c.resolving[layer] {
+		// Anchors form a cycle, so ignore them.
+		return c.offsetOrigin(layer)
+	}
+
+	c.resolving[layer] = true
+	var origin image.Point
+	switch {
+	case layer.placed():
+		origin = c.placedOrigin(layer)
+	case layer.anchored():
+		origin = c.anchoredOrigin(layer)
+	default:
+		origin = c.offsetOrigin(layer)
+	}
+	delete(c.resolving, layer)
+
+	c.origins[layer] = origin
+	return origin
+}
+
+// offsetOrigin returns the origin of a layer positioned with its X and Y
+// coordinates relative to its parent.
+func (c *Compositor) offsetOrigin(layer *Layer) image.Point {
+	offset := image.Pt(layer.x, layer.y)
+	parent := layer.parent
+	if parent == nil || layer == c.root {
+		return offset
+	}
+	scroll := image.Pt(parent.scrollX, parent.scrollY)
+	return c.origin(parent).Sub(scroll).Add(offset)
+}
+
+// placedOrigin returns the origin of a layer placed inside its parent.
+func (c *Compositor) placedOrigin(layer *Layer) image.Point {
+	var area image.Rectangle
+	switch parent := layer.parent; {
+	case parent == nil:
+		return c.offsetOrigin(layer)
+	case parent == c.root:
+		area = c.scene
+	default:
+		width, height := parent.contentSize()
+		origin := c.origin(parent)
+		area = image.Rectangle{Min: origin, Max: origin.Add(image.Pt(width, height))}
+	}
+
+	width, height := layer.contentSize()
+	return image.Pt(
+		area.Min.X+placeIn(area.Dx(), width, layer.anchor.hPos)+layer.x,
+		area.Min.Y+placeIn(area.Dy(), height, layer.anchor.vPos)+layer.y,
+	)
+}
+
+// anchoredOrigin returns the origin of a layer anchored to another layer.
+func (c *Compositor) anchoredOrigin(layer *Layer) image.Point {
+	target := c.index[layer.anchor.target]
+	if target == nil || target == layer || !c.isVisible(target) {
+		return c.offsetOrigin(layer)
+	}
+
+	width, height := target.contentSize()
+	origin := c.origin(target)
+	targetBounds := image.Rectangle{Min: origin, Max: origin.Add(image.Pt(width, height))}
+
+	placement := layer.anchor.placement
+	rect := layer.anchorRect(targetBounds, placement, false)
+	if layer.flip {
+		boundary := c.boundary(layer)
+		if !boundary.Empty() && overflows(rect, boundary, placement) {
+			flipped := layer.anchorRect(targetBounds, placement.opposite(), true)
+			if !overflows(flipped, boundary, placement.opposite()) {
+				rect = flipped
+			}
+		}
+	}
+	return rect.Min
+}
+
+// anchorRect returns the bounds of the layer when anchored to the target
+// bounds with the given placement. If mirrored, the offset along the main
+// axis is inverted, so gaps are kept when flipping.
+func (l *Layer) anchorRect(target image.Rectangle, placement Placement, mirrored bool) image.Rectangle {
+	width, height := l.contentSize()
+	dx, dy := l.x, l.y
+
+	var pt image.Point
+	switch placement {
+	case Above, Below:
+		pt.X = target.Min.X + placeIn(target.Dx(), width, l.anchor.align)
+		if placement == Above {
+			pt.Y = target.Min.Y - height
+		} else {
+			pt.Y = target.Max.Y
+		}
+		if mirrored {
+			dy = -dy
+		}
+	case LeftOf, RightOf:
+		pt.Y = target.Min.Y + placeIn(target.Dy(), height, l.anchor.align)
+		if placement == LeftOf {
+			pt.X = target.Min.X - width
+		} else {
+			pt.X = target.Max.X
+		}
+		if mirrored {
+			dx = -dx
+		}
+	}
+
+	pt = pt.Add(image.Pt(dx, dy))
+	return image.Rectangle{Min: pt, Max: pt.Add(image.Pt(width, height))}
+}
+
+// overflows returns whether the rectangle overflows the boundary on the side
+// it's placed on.
+func overflows(rect, boundary image.Rectangle, placement Placement) bool {
+	switch placement {
+	case Above:
+		return rect.Min.Y < boundary.Min.Y
+	case Below:
+		return rect.Max.Y > boundary.Max.Y
+	case LeftOf:
+		return rect.Min.X < boundary.Min.X
+	default:
+		return rect.Max.X > boundary.Max.X
+	}
+}
+
+// boundary returns the area a layer has to fit in: the bounds of the scene,
+// intersected with the clip rectangles of the layer's ancestors.
+func (c *Compositor) boundary(layer *Layer) image.Rectangle {
+	boundary := c.scene
+	for parent := layer.parent; parent != nil; parent = parent.parent {
+		if parent.clipped {
+			boundary = boundary.Intersect(parent.clip.Add(c.origin(parent)))
+		}
+	}
+	return boundary
+}
+
+// isVisible returns whether neither the layer nor any of its ancestors is
+// hidden.
+func (c *Compositor) isVisible(layer *Layer) bool {
+	for ; layer != nil; layer = layer.parent {
+		if layer.hidden {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,135 @@
+package lipgloss
+
+import (
+	"image"
+	"strings"
+	"testing"
+)
+
+func newAnchorTestScreen() *Layer {
+	return NewLayer(strings.Join([]string{
+		"..........",
+		"..........",
+		"..........",
+		"..........",
+		"..........",
+	}, "\n")).ID("screen")
+}
+
+func TestLayerPlace(t *testing.T) {
+	tests := []struct {
+		name       string
+		hPos, vPos Position
+		x, y       int
+		expected   image.Point
+	}{
+		{"top left", Left, Top, 0, 0, image.Pt(0, 0)},
+		{"center", Center, Center, 0, 0, image.Pt(4, 2)},
+		{"bottom right", Right, Bottom, 0, 0, image.Pt(8, 4)},
+		{"bottom right with inset", Right, Bottom, -1, -1, image.Pt(7, 3)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			popup := NewLayer("ab").ID("popup").Place(tc.hPos, tc.vPos).X(tc.x).Y(tc.y)
+			screen := newAnchorTestScreen().AddLayers(popup)
+			comp := NewCompositor(screen)
+
+			hit := comp.Hit(tc.expected.X, tc.expected.Y)
+			if hit.ID() != "popup" || hit.Bounds().Min != tc.expected {
+				t.Errorf("expected popup at %v, got %q at %v", tc.expected, hit.ID(), hit.Bounds().Min)
+			}
+			if screen.Width() != 10 || screen.Height() != 5 {
+				t.Errorf("expected parent size 10x5, got %dx%d", screen.Width(), screen.Height())
+			}
+		})
+	}
+}
+
+func TestLayerPlaceTopLevel(t *testing.T) {
+	// Top-level layers are placed within the rest of the scene.
+	comp := NewCompositor(
+		newAnchorTestScreen(),
+		NewLayer("ab").ID("popup").Place(Center, Center),
+	)
+	if hit := comp.Hit(4, 2); hit.ID() != "popup" {
+		t.Errorf("expected hit on %q, got %q", "popup", hit.ID())
+	}
+
+	got := comp.Render()
+	expected := strings.Join([]string{
+		"..........",
+		"..........",
+		"....ab....",
+		"..........",
+		"..........",
+	}, "\n")
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestLayerAnchorTo(t *testing.T) {
+	tests := []struct {
+		name      string
+		placement Placement
+		align     Position
+		expected  image.Point
+	}{
+		{"above", Above, Left, image.Pt(3, 0)},
+		{"below", Below, Left, image.Pt(3, 3)},
+		{"below right", Below, Right, image.Pt(4, 3)},
+		{"left of", LeftOf, Top, image.Pt(1, 1)},
+		{"right of", RightOf, Bottom, image.Pt(6, 2)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			menu := NewLayer("...\n...").ID("menu").X(3).Y(1)
+			tip := NewLayer("ab").ID("tip").AnchorTo("menu", tc.placement, tc.align)
+			comp := NewCompositor(newAnchorTestScreen().AddLayers(menu, tip))
+
+			hit := comp.Hit(tc.expected.X, tc.expected.Y)
+			if hit.ID() != "tip" || hit.Bounds().Min != tc.expected {
+				t.Errorf("expected tip at %v, got %q at %v", tc.expected, hit.ID(), hit.Bounds().Min)
+			}
+		})
+	}
+}
+
+func TestLayerAnchorFlip(t *testing.T) {
+	button := NewLayer("btn").ID("button").X(2).Y(3)
+	dropdown := NewLayer("a\nb").ID("dropdown").AnchorTo("button", Below, Left).Y(1)
+	comp := NewCompositor(newAnchorTestScreen().AddLayers(button, dropdown))
+
+	// There's no room below the button, but flipping is disabled.
+	if hit := comp.Hit(2, 5); hit.ID() != "dropdown" || hit.Bounds().Min != image.Pt(2, 5) {
+		t.Errorf("expected dropdown at (2,5), got %q at %v", hit.ID(), hit.Bounds().Min)
+	}
+
+	// The gap between the button and the dropdown is kept when flipping.
+	dropdown.Flip(true)
+	if hit := comp.Hit(2, 0); hit.ID() != "dropdown" || hit.Bounds().Min != image.Pt(2, 0) {
+		t.Errorf("expected dropdown at (2,0), got %q at %v", hit.ID(), hit.Bounds().Min)
+	}
+
+	// There's enough room below.
+	button.Y(0)
+	if hit := comp.Hit(2, 2); hit.ID() != "dropdown" || hit.Bounds().Min != image.Pt(2, 2) {
+		t.Errorf("expected dropdown at (2,2), got %q at %v", hit.ID(), hit.Bounds().Min)
+	}
+}
+
+func TestLayerAnchorMissingTarget(t *testing.T) {
+	tip := NewLayer("ab").ID("tip").AnchorTo("nope", Below, Left).X(1)
+	comp := NewCompositor(newAnchorTestScreen().AddLayers(tip))
+	if hit := comp.Hit(1, 0); hit.ID() != "tip" {
+		t.Errorf("expected hit on %q, got %q", "tip", hit.ID())
+	}
+
+	// Cycles are ignored.
+	a := NewLayer("a").ID("a").AnchorTo("b", RightOf, Top)
+	b := NewLayer("b").ID("b").AnchorTo("a", RightOf, Top)
+	comp = NewCompositor(a, b)
+	if got := comp.Render(); got == "" {
+		t.Error("expected layers to be rendered")
+	}
+}
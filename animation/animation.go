@@ -0,0 +1,131 @@
+// Package animation animates numbers and colors over time, with easing
+// functions, and applies them to Lip Gloss layers and styles.
+//
+// An [Animation] is a set of [Tween]s bound to setters, driven by a [Clock].
+// Each frame, [Animation.Update] computes the value of every tween at the
+// current time and passes it to its setter. See the package example for an
+// animation that moves a layer and fades its color.
+//
+// Animations read the time from their clock, so they can be tested
+// deterministically with a [ManualClock].
+package animation
+
+import (
+	"time"
+)
+
+// DefaultFPS is the frame rate [Animation.Play] uses when given a frame rate
+// of 0 or less.
+const DefaultFPS = 60
+
+// Animation drives a set of [Tween]s with a [Clock], applying their values to
+// properties through setters. Add tweens with [Animate].
+type Animation struct {
+	clock   Clock
+	start   time.Time
+	started bool
+	tracks  []track
+}
+
+// track is a tween bound to a setter.
+type track struct {
+	apply    func(elapsed time.Duration)
+	duration time.Duration
+}
+
+// New returns a new, empty, [Animation] driven by the given clock. If the
+// clock is nil, the system clock is used.
+func New(clock Clock) *Animation {
+	if clock == nil {
+		clock = SystemClock{}
+	}
+	return &Animation{clock: clock}
+}
+
+// Animate adds a [Tween] to the animation. Every update, the value of the
+// tween is passed to set. Use the setters in this package, like [LayerX] and
+// [Foreground], or any function that takes the value.
+func Animate[T any](a *Animation, t *Tween[T], set func(T)) *Animation {
+	a.tracks = append(a.tracks, track{
+		apply:    func(elapsed time.Duration) { set(t.At(elapsed)) },
+		duration: t.Duration(),
+	})
+	return a
+}
+
+// Start starts the animation at the current time of its clock. Animations
+// start on their first update if they haven't been started before.
+func (a *Animation) Start() *Animation {
+	a.start = a.clock.Now()
+	a.started = true
+	return a
+}
+
+// Reset rewinds the animation, so it starts again on its next update.
+func (a *Animation) Reset() *Animation {
+	a.started = false
+	return a
+}
+
+// Elapsed returns the time since the animation started.
+func (a *Animation) Elapsed() time.Duration {
+	if !a.started {
+		return 0
+	}
+	return a.clock.Now().Sub(a.start)
+}
+
+// Duration returns the total duration of the animation, which is the
+// duration of its longest tween. It returns -1 if a tween repeats forever.
+func (a *Animation) Duration() time.Duration {
+	var d time.Duration
+	for _, t := range a.tracks {
+		if t.duration < 0 {
+			return -1
+		}
+		d = max(d, t.duration)
+	}
+	return d
+}
+
+// Done returns whether all tweens of the animation have finished.
+func (a *Animation) Done() bool {
+	d := a.Duration()
+	return a.started && d >= 0 && a.Elapsed() >= d
+}
+
+// Update applies the values of all tweens at the current time, and reports
+// whether the animation is still running. Call it before rendering each
+// frame, for instance when handling a tick message in Bubble Tea.
+func (a *Animation) Update() bool {
+	if !a.started {
+		a.Start()
+	}
+	elapsed := a.Elapsed()
+	for _, t := range a.tracks {
+		t.apply(elapsed)
+	}
+	return !a.Done()
+}
+
+// Play runs the animation to completion at the given frame rate, calling
+// frame after each update. It blocks until the animation is done, so
+// animations that repeat forever never return. With a [ManualClock], it
+// returns right away, having produced every frame.
+func (a *Animation) Play(fps int, frame func()) {
+	if fps <= 0 {
+		fps = DefaultFPS
+	}
+	interval := time.Second / time.Duration(fps)
+	a.Start()
+	for {
+		running := a.Update()
+		if frame != nil {
+			frame()
+		}
+		if !running {
+			return
+		}
+		a.clock.Sleep(interval)
+	}
+}
@@ -0,0 +1,187 @@
+package animation_test
+
+import (
+	"image/color"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"charm.land/lipgloss/v2"
+	"charm.land/lipgloss/v2/animation"
+)
+
+func TestTween(t *testing.T) {
+	tw := animation.Int(0, 100, time.Second)
+	tests := []struct {
+		elapsed  time.Duration
+		expected int
+	}{
+		{-time.Second, 0},
+		{0, 0},
+		{250 * time.Millisecond, 25},
+		{500 * time.Millisecond, 50},
+		{time.Second, 100},
+		{2 * time.Second, 100},
+	}
+	for _, tc := range tests {
+		if got := tw.At(tc.elapsed); got != tc.expected {
+			t.Errorf("at %s: expected %d, got %d", tc.elapsed, tc.expected, got)
+		}
+	}
+}
+
+func TestTweenDelayRepeatYoyo(t *testing.T) {
+	tw := animation.Int(0, 10, time.Second).
+		Delay(time.Second).
+		Repeat(2).
+		Yoyo(true)
+
+	if got, expected := tw.Duration(), 4*time.Second; got != expected {
+		t.Errorf("expected a duration of %s, got %s", expected, got)
+	}
+	tests := []struct {
+		elapsed  time.Duration
+		expected int
+	}{
+		{500 * time.Millisecond, 0},  // delayed
+		{1500 * time.Millisecond, 5}, // first iteration
+		{2250 * time.Millisecond, 8}, // second iteration runs backwards
+		{3250 * time.Millisecond, 3}, // third iteration runs forwards
+		{10 * time.Second, 10},       // done, at the end of the last iteration
+	}
+	for _, tc := range tests {
+		if got := tw.At(tc.elapsed); got != tc.expected {
+			t.Errorf("at %s: expected %d, got %d", tc.elapsed, tc.expected, got)
+		}
+	}
+
+	forever := animation.Number(0, 1, time.Second).Repeat(animation.Forever)
+	if forever.Duration() != -1 || forever.Done(time.Hour) {
+		t.Error("expected a tween that repeats forever never to be done")
+	}
+	if got := forever.At(time.Hour + 500*time.Millisecond); got != 0.5 {
+		t.Errorf("expected 0.5, got %v", got)
+	}
+}
+
+func TestEasing(t *testing.T) {
+	easings := map[string]animation.Easing{
+		"Linear":         animation.Linear,
+		"EaseInQuad":     animation.EaseInQuad,
+		"EaseOutQuad":    animation.EaseOutQuad,
+		"EaseInOutQuad":  animation.EaseInOutQuad,
+		"EaseInCubic":    animation.EaseInCubic,
+		"EaseOutCubic":   animation.EaseOutCubic,
+		"EaseInOutCubic": animation.EaseInOutCubic,
+		"EaseInSine":     animation.EaseInSine,
+		"EaseOutSine":    animation.EaseOutSine,
+		"EaseInOutSine":  animation.EaseInOutSine,
+		"EaseInExpo":     animation.EaseInExpo,
+		"EaseOutExpo":    animation.EaseOutExpo,
+		"EaseInBack":     animation.EaseInBack,
+		"EaseOutBack":    animation.EaseOutBack,
+		"EaseOutElastic": animation.EaseOutElastic,
+		"EaseOutBounce":  animation.EaseOutBounce,
+	}
+	for name, e := range easings {
+		if got := e(0); math.Abs(got) > 1e-3 {
+			t.Errorf("%s(0): expected 0, got %v", name, got)
+		}
+		if got := e(1); math.Abs(got-1) > 1e-3 {
+			t.Errorf("%s(1): expected 1, got %v", name, got)
+		}
+	}
+
+	if got := animation.EaseInQuad(0.5); got != 0.25 {
+		t.Errorf("expected 0.25, got %v", got)
+	}
+	if got := animation.EaseOutBack(0.8); got <= 1 {
+		t.Errorf("expected EaseOutBack to overshoot, got %v", got)
+	}
+}
+
+func TestLerpColor(t *testing.T) {
+	from, to := lipgloss.Color("#ff0000"), lipgloss.Color("#0000ff")
+	if got := toRGBA(animation.LerpColor(from, to, 0)); got != toRGBA(from) {
+		t.Errorf("expected the start color, got %v", got)
+	}
+	if got := toRGBA(animation.LerpColor(from, to, 1)); got != toRGBA(to) {
+		t.Errorf("expected the end color, got %v", got)
+	}
+	if got := animation.LerpColor(nil, to, 0.5); got != nil {
+		t.Errorf("expected nil halfway from nil, got %v", got)
+	}
+}
+
+func TestAnimation(t *testing.T) {
+	clock := animation.NewManualClock(time.Unix(0, 0))
+	layer := lipgloss.NewLayer("hi")
+	style := lipgloss.NewStyle()
+
+	a := animation.New(clock)
+	animation.Animate(a, animation.Int(0, 10, time.Second), animation.LayerX(layer))
+	animation.Animate(a, animation.Int(0, 4, 500*time.Millisecond).Easing(animation.EaseInQuad), animation.LayerY(layer))
+	animation.Animate(a, animation.Color(lipgloss.Color("#000000"), lipgloss.Color("#ffffff"), time.Second),
+		animation.Foreground(&style))
+	animation.Animate(a, animation.Int(0, 20, time.Second), animation.BorderForegroundBlendOffset(&style))
+
+	if a.Duration() != time.Second {
+		t.Errorf("expected a duration of 1s, got %s", a.Duration())
+	}
+
+	if !a.Update() {
+		t.Error("expected the animation to be running")
+	}
+	if layer.GetX() != 0 || layer.GetY() != 0 {
+		t.Errorf("expected the layer at (0, 0), got (%d, %d)", layer.GetX(), layer.GetY())
+	}
+
+	clock.Advance(250 * time.Millisecond)
+	a.Update()
+	if layer.GetX() != 3 || layer.GetY() != 1 {
+		t.Errorf("expected the layer at (3, 1), got (%d, %d)", layer.GetX(), layer.GetY())
+	}
+	if got := style.GetBorderForegroundBlendOffset(); got != 5 {
+		t.Errorf("expected a blend offset of 5, got %d", got)
+	}
+
+	clock.Advance(time.Second)
+	if a.Update() {
+		t.Error("expected the animation to be done")
+	}
+	if layer.GetX() != 10 || layer.GetY() != 4 {
+		t.Errorf("expected the layer at (10, 4), got (%d, %d)", layer.GetX(), layer.GetY())
+	}
+	if got := toRGBA(style.GetForeground()); got != (color.RGBA{0xff, 0xff, 0xff, 0xff}) {
+		t.Errorf("expected a white foreground, got %v", got)
+	}
+
+	// Resetting starts the animation over.
+	a.Reset().Update()
+	if layer.GetX() != 0 {
+		t.Errorf("expected the layer back at 0, got %d", layer.GetX())
+	}
+}
+
+func TestAnimationPlay(t *testing.T) {
+	clock := animation.NewManualClock(time.Unix(0, 0))
+	var xs []int
+	layer := lipgloss.NewLayer("hi")
+	a := animation.New(clock)
+	animation.Animate(a, animation.Int(0, 4, 100*time.Millisecond), animation.LayerX(layer))
+
+	a.Play(40, func() { xs = append(xs, layer.GetX()) })
+
+	if expected := []int{0, 1, 2, 3, 4}; !slices.Equal(xs, expected) {
+		t.Errorf("expected frames %v, got %v", expected, xs)
+	}
+	if got := clock.Now(); got != time.Unix(0, 0).Add(100*time.Millisecond) {
+		t.Errorf("expected the clock to have advanced by 100ms, got %s", got)
+	}
+}
+
+func toRGBA(c color.Color) color.RGBA {
+	r, g, b, a := c.RGBA()
+	return color.RGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), uint8(a >> 8)}
+}
@@ -0,0 +1,68 @@
+package animation
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the time and waits. Animations read the time from their clock,
+// so a [ManualClock] makes them deterministic.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// Sleep waits for the given duration.
+	Sleep(d time.Duration)
+}
+
+// SystemClock is a [Clock] that uses the system time.
+type SystemClock struct{}
+
+// Now implements [Clock].
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Sleep implements [Clock].
+func (SystemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// ManualClock is a [Clock] that only moves forward when told to. Sleeping
+// advances it instantly, so animations played with [Animation.Play] run to
+// completion without waiting. It's safe for concurrent use.
+type ManualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewManualClock returns a new [ManualClock] set to the given time.
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+// Now implements [Clock].
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Sleep implements [Clock]. It advances the clock by the given duration.
+func (c *ManualClock) Sleep(d time.Duration) {
+	c.Advance(d)
+}
+
+// Advance moves the clock forward by the given duration.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set sets the time of the clock.
+func (c *ManualClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
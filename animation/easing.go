@@ -0,0 +1,128 @@
+package animation
+
+import "math"
+
+// Easing maps the linear progress of a [Tween], from 0 to 1, to the eased
+// progress. Some easing functions overshoot, returning values outside of
+// [0, 1] in between.
+type Easing func(t float64) float64
+
+// Linear progresses at a constant rate.
+func Linear(t float64) float64 {
+	return t
+}
+
+// EaseInQuad starts slow and accelerates.
+func EaseInQuad(t float64) float64 {
+	return t * t
+}
+
+// EaseOutQuad starts fast and decelerates.
+func EaseOutQuad(t float64) float64 {
+	return 1 - (1-t)*(1-t)
+}
+
+// EaseInOutQuad accelerates until halfway, then decelerates.
+func EaseInOutQuad(t float64) float64 {
+	if t < 0.5 { //nolint:mnd
+		return 2 * t * t //nolint:mnd
+	}
+	return 1 - math.Pow(-2*t+2, 2)/2 //nolint:mnd
+}
+
+// EaseInCubic starts slow and accelerates, more sharply than [EaseInQuad].
+func EaseInCubic(t float64) float64 {
+	return t * t * t
+}
+
+// EaseOutCubic starts fast and decelerates, more sharply than
+// [EaseOutQuad].
+func EaseOutCubic(t float64) float64 {
+	return 1 - math.Pow(1-t, 3) //nolint:mnd
+}
+
+// EaseInOutCubic accelerates until halfway, then decelerates, more sharply
+// than [EaseInOutQuad].
+func EaseInOutCubic(t float64) float64 {
+	if t < 0.5 { //nolint:mnd
+		return 4 * t * t * t //nolint:mnd
+	}
+	return 1 - math.Pow(-2*t+2, 3)/2 //nolint:mnd
+}
+
+// EaseInSine starts slow and accelerates gently.
+func EaseInSine(t float64) float64 {
+	return 1 - math.Cos(t*math.Pi/2) //nolint:mnd
+}
+
+// EaseOutSine starts fast and decelerates gently.
+func EaseOutSine(t float64) float64 {
+	return math.Sin(t * math.Pi / 2) //nolint:mnd
+}
+
+// EaseInOutSine accelerates gently until halfway, then decelerates.
+func EaseInOutSine(t float64) float64 {
+	return -(math.Cos(math.Pi*t) - 1) / 2 //nolint:mnd
+}
+
+// EaseInExpo starts very slow and accelerates exponentially.
+func EaseInExpo(t float64) float64 {
+	if t <= 0 {
+		return 0
+	}
+	return math.Pow(2, 10*t-10) //nolint:mnd
+}
+
+// EaseOutExpo starts very fast and decelerates exponentially.
+func EaseOutExpo(t float64) float64 {
+	if t >= 1 {
+		return 1
+	}
+	return 1 - math.Pow(2, -10*t) //nolint:mnd
+}
+
+// EaseInBack pulls back a little before moving forward.
+func EaseInBack(t float64) float64 {
+	const c1 = 1.70158
+	return (c1+1)*t*t*t - c1*t*t
+}
+
+// EaseOutBack overshoots the end a little before settling.
+func EaseOutBack(t float64) float64 {
+	const c1 = 1.70158
+	return 1 + (c1+1)*math.Pow(t-1, 3) + c1*math.Pow(t-1, 2) //nolint:mnd
+}
+
+// EaseOutElastic overshoots the end and oscillates before settling, like a
+// spring.
+func EaseOutElastic(t float64) float64 {
+	switch {
+	case t <= 0:
+		return 0
+	case t >= 1:
+		return 1
+	}
+	const c4 = 2 * math.Pi / 3
+	return math.Pow(2, -10*t)*math.Sin((t*10-0.75)*c4) + 1 //nolint:mnd
+}
+
+// EaseOutBounce bounces at the end, like a dropped ball.
+func EaseOutBounce(t float64) float64 {
+	const (
+		n1 = 7.5625
+		d1 = 2.75
+	)
+	switch {
+	case t < 1/d1:
+		return n1 * t * t
+	case t < 2/d1:
+		t -= 1.5 / d1
+		return n1*t*t + 0.75 //nolint:mnd
+	case t < 2.5/d1:
+		t -= 2.25 / d1
+		return n1*t*t + 0.9375 //nolint:mnd
+	default:
+		t -= 2.625 / d1
+		return n1*t*t + 0.984375 //nolint:mnd
+	}
+}
@@ -0,0 +1,38 @@
+package animation_test
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/lipgloss/v2"
+	"charm.land/lipgloss/v2/animation"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func Example() {
+	layer := lipgloss.NewLayer("Hello!")
+	style := lipgloss.NewStyle()
+
+	// A manual clock makes every frame deterministic. Pass nil to use the
+	// system clock instead.
+	a := animation.New(animation.NewManualClock(time.Time{}))
+	animation.Animate(a, animation.Int(0, 20, time.Second).Easing(animation.EaseOutCubic),
+		animation.LayerX(layer))
+	animation.Animate(a, animation.Color(lipgloss.Color("#00FA68"), lipgloss.Color("#9900FF"), time.Second),
+		animation.Foreground(&style))
+
+	canvas := lipgloss.NewCanvas(26, 1)
+	a.Play(4, func() {
+		layer.ReplaceContent(style.Render("Hello!"))
+		canvas.Clear()
+		canvas.Compose(lipgloss.NewCompositor(layer))
+		fmt.Println(strings.TrimRight(ansi.Strip(canvas.Render()), " "))
+	})
+	// Output:
+	// Hello!
+	//             Hello!
+	//                   Hello!
+	//                     Hello!
+	//                     Hello!
+}
@@ -0,0 +1,46 @@
+package animation
+
+import (
+	"image/color"
+
+	"charm.land/lipgloss/v2"
+)
+
+// LayerX returns a setter for the X coordinate of a [lipgloss.Layer].
+func LayerX(l *lipgloss.Layer) func(int) {
+	return func(x int) { l.X(x) }
+}
+
+// LayerY returns a setter for the Y coordinate of a [lipgloss.Layer].
+func LayerY(l *lipgloss.Layer) func(int) {
+	return func(y int) { l.Y(y) }
+}
+
+// LayerOpacity returns a setter for the opacity of a [lipgloss.Layer].
+func LayerOpacity(l *lipgloss.Layer) func(float64) {
+	return func(o float64) { l.Opacity(o) }
+}
+
+// Foreground returns a setter for the foreground color of a style. Styles
+// are values, so it takes a pointer to the style to update.
+func Foreground(s *lipgloss.Style) func(color.Color) {
+	return func(c color.Color) { *s = s.Foreground(c) }
+}
+
+// Background returns a setter for the background color of a style.
+func Background(s *lipgloss.Style) func(color.Color) {
+	return func(c color.Color) { *s = s.Background(c) }
+}
+
+// BorderForeground returns a setter for the foreground color of all borders
+// of a style.
+func BorderForeground(s *lipgloss.Style) func(color.Color) {
+	return func(c color.Color) { *s = s.BorderForeground(c) }
+}
+
+// BorderForegroundBlendOffset returns a setter for the offset of the border
+// blend of a style, which rotates the gradient around the border. Use it with
+// a [Tween] that repeats [Forever] to keep the border spinning.
+func BorderForegroundBlendOffset(s *lipgloss.Style) func(int) {
+	return func(o int) { *s = s.BorderForegroundBlendOffset(o) }
+}
@@ -0,0 +1,173 @@
+package animation
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"github.com/lucasb-eyer/go-colorful"
+)
+
+// Forever makes a [Tween] repeat indefinitely. See [Tween.Repeat].
+const Forever = -1
+
+// Lerp interpolates between two values. At t = 0 it returns a, and at t = 1
+// it returns b. Easing functions may pass values of t outside of [0, 1].
+type Lerp[T any] func(a, b T, t float64) T
+
+// Tween interpolates a value from one value to another over a duration. A
+// Tween doesn't keep track of time itself: [Tween.At] returns its value at a
+// given time since it started, which makes it easy to test. Use an
+// [Animation] to drive tweens with a [Clock].
+type Tween[T any] struct {
+	from, to T
+	lerp     Lerp[T]
+	duration time.Duration
+	delay    time.Duration
+	easing   Easing
+	repeat   int
+	yoyo     bool
+}
+
+// NewTween returns a new [Tween] from one value to another, interpolated
+// with the given function.
+func NewTween[T any](from, to T, duration time.Duration, lerp Lerp[T]) *Tween[T] {
+	return &Tween[T]{
+		from:     from,
+		to:       to,
+		lerp:     lerp,
+		duration: max(duration, 0),
+		easing:   Linear,
+	}
+}
+
+// Number returns a new [Tween] between two numbers.
+func Number(from, to float64, duration time.Duration) *Tween[float64] {
+	return NewTween(from, to, duration, LerpNumber)
+}
+
+// Int returns a new [Tween] between two integers, like coordinates or
+// offsets. Values are rounded to the nearest integer.
+func Int(from, to int, duration time.Duration) *Tween[int] {
+	return NewTween(from, to, duration, LerpInt)
+}
+
+// Color returns a new [Tween] between two colors. Colors are blended in the
+// CIELAB color space, like [lipgloss.Blend1D] does.
+func Color(from, to color.Color, duration time.Duration) *Tween[color.Color] {
+	return NewTween(from, to, duration, LerpColor)
+}
+
+// LerpNumber interpolates linearly between two numbers.
+func LerpNumber(a, b float64, t float64) float64 {
+	return a + (b-a)*t
+}
+
+// LerpInt interpolates linearly between two integers, rounding to the
+// nearest one.
+func LerpInt(a, b int, t float64) int {
+	return int(math.Round(LerpNumber(float64(a), float64(b), t)))
+}
+
+// LerpColor blends two colors in the CIELAB color space. If either color is
+// nil, it returns a until t reaches 1 and b afterwards.
+func LerpColor(a, b color.Color, t float64) color.Color {
+	ca, okA := makeColor(a)
+	cb, okB := makeColor(b)
+	if !okA || !okB {
+		if t >= 1 {
+			return b
+		}
+		return a
+	}
+	return ca.BlendLab(cb, t).Clamped()
+}
+
+// makeColor converts a color to a [colorful.Color], treating fully
+// transparent colors as opaque.
+func makeColor(c color.Color) (colorful.Color, bool) {
+	if c == nil {
+		return colorful.Color{}, false
+	}
+	r, g, b, _ := c.RGBA()
+	return colorful.Color{
+		R: float64(r) / 0xffff,
+		G: float64(g) / 0xffff,
+		B: float64(b) / 0xffff,
+	}, true
+}
+
+// Easing sets the easing function of the Tween. The default is [Linear].
+func (t *Tween[T]) Easing(e Easing) *Tween[T] {
+	if e != nil {
+		t.easing = e
+	}
+	return t
+}
+
+// Delay sets how long the Tween waits before it starts moving. During the
+// delay, its value is the starting value.
+func (t *Tween[T]) Delay(d time.Duration) *Tween[T] {
+	t.delay = max(d, 0)
+	return t
+}
+
+// Repeat sets how many times the Tween repeats after it first finishes. Use
+// [Forever] to repeat indefinitely.
+func (t *Tween[T]) Repeat(n int) *Tween[T] {
+	t.repeat = max(n, Forever)
+	return t
+}
+
+// Yoyo sets whether every other repetition of the Tween runs backwards, from
+// the end value to the start value.
+func (t *Tween[T]) Yoyo(yoyo bool) *Tween[T] {
+	t.yoyo = yoyo
+	return t
+}
+
+// Duration returns the total duration of the Tween, including its delay and
+// repetitions. It returns -1 if the Tween repeats forever.
+func (t *Tween[T]) Duration() time.Duration {
+	if t.repeat == Forever {
+		return -1
+	}
+	return t.delay + t.duration*time.Duration(t.repeat+1)
+}
+
+// Done returns whether the Tween has finished at the given time since it
+// started.
+func (t *Tween[T]) Done(elapsed time.Duration) bool {
+	d := t.Duration()
+	return d >= 0 && elapsed >= d
+}
+
+// Progress returns the eased progress of the Tween at the given time since
+// it started, taking repetitions and yoyo into account.
+func (t *Tween[T]) Progress(elapsed time.Duration) float64 {
+	elapsed -= t.delay
+	if elapsed < 0 {
+		return t.easing(0)
+	}
+
+	var iteration int
+	var p float64
+	switch {
+	case t.duration == 0 || t.Done(elapsed+t.delay):
+		// Stay at the end of the last iteration.
+		iteration = max(t.repeat, 0)
+		p = 1
+	default:
+		iteration = int(elapsed / t.duration)
+		p = float64(elapsed%t.duration) / float64(t.duration)
+	}
+	if t.yoyo && iteration%2 == 1 {
+		p = 1 - p
+	}
+	return t.easing(p)
+}
+
+// At returns the value of the Tween at the given time since it started.
+func (t *Tween[T]) At(elapsed time.Duration) T {
+	return t.lerp(t.from, t.to, t.Progress(elapsed))
+}
@@ -0,0 +1,66 @@
+package lipgloss
+
+import "image"
+
+// Camera sets the area of the scene, in scene coordinates, that the
+// [Compositor] renders. Layers outside of it are clipped, and its top-left
+// corner becomes the top-left corner of the output. This makes it possible to
+// render a part of a scene that's larger than the screen, and to move around
+// it with [Compositor.Pan].
+//
+// While a camera is set, coordinates passed to [Compositor.Hit],
+// [Compositor.HitAll] and [Compositor.HandleMouse] are relative to the
+// camera, like the coordinates of mouse events are relative to the screen.
+func (c *Compositor) Camera(rect image.Rectangle) *Compositor {
+	c.camera = rect.Canon()
+	c.hasCamera = true
+	return c
+}
+
+// UnsetCamera removes the camera, so that the whole scene is rendered.
+func (c *Compositor) UnsetCamera() *Compositor {
+	c.camera = image.Rectangle{}
+	c.hasCamera = false
+	return c
+}
+
+// GetCamera returns the camera rectangle and whether a camera is set.
+func (c *Compositor) GetCamera() (image.Rectangle, bool) {
+	return c.camera, c.hasCamera
+}
+
+// Pan moves the camera by the given amount. If no camera is set, the current
+// view becomes the camera first.
+func (c *Compositor) Pan(dx, dy int) *Compositor {
+	if !c.hasCamera {
+		c.Camera(c.View())
+	}
+	c.camera = c.camera.Add(image.Pt(dx, dy))
+	return c
+}
+
+// View returns the area of the scene, in scene coordinates, that the
+// [Compositor] renders. That's the camera if one is set. Otherwise, it's the
+// bounds of all layers, extended to include the origin, so that layers at
+// positive offsets keep their offsets and layers at negative offsets are not
+// cut off.
+func (c *Compositor) View() image.Rectangle {
+	if c.hasCamera {
+		return c.camera
+	}
+	c.update()
+	return image.Rectangle{
+		Min: image.Pt(min(c.bounds.Min.X, 0), min(c.bounds.Min.Y, 0)),
+		Max: image.Pt(max(c.bounds.Max.X, 0), max(c.bounds.Max.Y, 0)),
+	}
+}
+
+// scenePoint converts coordinates relative to the camera, if any, into scene
+// coordinates.
+func (c *Compositor) scenePoint(x, y int) image.Point {
+	pt := image.Pt(x, y)
+	if c.hasCamera {
+		pt = pt.Add(c.camera.Min)
+	}
+	return pt
+}
@@ -0,0 +1,101 @@
+package lipgloss
+
+import (
+	"image"
+	"strings"
+	"testing"
+)
+
+func TestCompositorRenderNegativeOffsets(t *testing.T) {
+	comp := NewCompositor(
+		NewLayer("ab\ncd").X(-1).Y(-1),
+		NewLayer("x").X(1).Y(1),
+	)
+
+	if view := comp.View(); view != image.Rect(-1, -1, 2, 2) {
+		t.Errorf("expected view %v, got %v", image.Rect(-1, -1, 2, 2), view)
+	}
+
+	got := comp.Render()
+	expected := strings.Join([]string{
+		"ab",
+		"cd",
+		"  x",
+	}, "\n")
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestCompositorRenderPositiveOffsets(t *testing.T) {
+	// Layers at positive offsets keep their offsets.
+	got := NewCompositor(NewLayer("ab").X(2).Y(1)).Render()
+	expected := strings.Join([]string{
+		"",
+		"  ab",
+	}, "\n")
+	if got != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, got)
+	}
+}
+
+func TestCompositorCamera(t *testing.T) {
+	scene := NewLayer(strings.Join([]string{
+		"abcdef",
+		"ghijkl",
+		"mnopqr",
+	}, "\n")).ID("scene").X(-2)
+	marker := NewLayer("*").ID("marker").X(1).Y(1)
+	comp := NewCompositor(scene, marker).Camera(image.Rect(0, 1, 3, 3))
+
+	got := comp.Render()
+	expected := strings.Join([]string{
+		"i*k",
+		"opq",
+	}, "\n")
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	// Hit testing is relative to the camera.
+	if hit := comp.Hit(1, 0); hit.ID() != "marker" {
+		t.Errorf("expected hit on %q, got %q", "marker", hit.ID())
+	}
+	if hit := comp.Hit(0, 0); hit.ID() != "scene" || hit.Local() != image.Pt(2, 1) {
+		t.Errorf("expected hit on %q at (2,1), got %q at %v", "scene", hit.ID(), hit.Local())
+	}
+
+	comp.Pan(-2, -1)
+	got = comp.Render()
+	expected = strings.Join([]string{
+		"abc",
+		"ghi",
+	}, "\n")
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	comp.UnsetCamera()
+	if _, ok := comp.GetCamera(); ok {
+		t.Error("expected no camera")
+	}
+	if got := comp.Render(); !strings.HasPrefix(got, "abcdef") {
+		t.Errorf("expected the whole scene, got:\n%s", got)
+	}
+}
+
+func TestCompositorDrawArea(t *testing.T) {
+	comp := NewCompositor(NewLayer("ab\ncd")).Camera(image.Rect(1, 0, 2, 2))
+
+	canvas := NewCanvas(4, 2)
+	canvas.Compose(NewLayer("....\n...."))
+	comp.Draw(canvas, image.Rect(2, 0, 4, 2))
+
+	expected := strings.Join([]string{
+		"..b.",
+		"..d.",
+	}, "\n")
+	if got := canvas.Render(); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
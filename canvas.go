@@ -1,6 +1,9 @@
 package lipgloss
 
 import (
+	"image"
+	"strings"
+
 	uv "github.com/charmbracelet/ultraviolet"
 	"github.com/charmbracelet/x/ansi"
 )
@@ -13,9 +16,17 @@ import (
 //
 // A canvas can read, modify, and render its cell contents.
 //
+// [Graphics] drawn onto a canvas aren't part of its cells: their cells are
+// left blank, and the image is emitted by [Canvas.Render] and
+// [FrameRenderer] after the cells. When cells of an image are drawn over, it
+// falls back to half blocks.
+//
 // It implements [uv.Screen] and [uv.Drawable].
 type Canvas struct {
 	scr uv.ScreenBuffer
+
+	// Graphics placed on the canvas, in the order they were drawn.
+	graphics []graphicsPlacement
 }
 
 var _ uv.Screen = (*Canvas)(nil)
@@ -31,11 +42,13 @@ func NewCanvas(width, height int) *Canvas {
 // Resize resizes the canvas to the given width and height.
 func (c *Canvas) Resize(width, height int) {
 	c.scr.Resize(width, height)
+	c.graphics = nil
 }
 
 // Clear clears the canvas.
 func (c *Canvas) Clear() {
 	c.scr.Clear()
+	c.graphics = nil
 }
 
 // Bounds implements [uv.Screen].
@@ -60,6 +73,13 @@ func (c *Canvas) CellAt(x int, y int) *uv.Cell {
 
 // SetCell implements [uv.Screen].
 func (c *Canvas) SetCell(x int, y int, cell *uv.Cell) {
+	if len(c.graphics) > 0 {
+		width := 1
+		if cell != nil {
+			width = max(cell.Width, 1)
+		}
+		c.occlude(image.Rect(x, y, x+width, y+1))
+	}
 	c.scr.SetCell(x, y, cell)
 }
 
@@ -80,9 +100,59 @@ func (c *Canvas) Compose(drawer uv.Drawable) *Canvas {
 // It implements [uv.Drawable].
 func (c *Canvas) Draw(scr uv.Screen, area uv.Rectangle) {
 	c.scr.Draw(scr, area)
+
+	// Graphics are placed again on the screen, or fall back to half blocks
+	// if it can't place them.
+	offset := area.Min.Sub(c.Bounds().Min)
+	clip := clipScreen{scr, area.Intersect(scr.Bounds())}
+	for _, p := range c.graphics {
+		p.graphics.Draw(clip, p.rect.Add(offset))
+	}
 }
 
 // Render renders the canvas into a styled string.
+//
+// The sequences of [Graphics] are appended after the cells. Each one saves
+// the cursor, moves it from the end of the last line to the top-left cell of
+// the image, and restores it, so the string must be printed where the
+// cursor is.
 func (c *Canvas) Render() string {
-	return c.scr.Render()
+	s := c.scr.Render()
+	if len(c.graphics) == 0 {
+		return s
+	}
+
+	var b strings.Builder
+	b.WriteString(s)
+	for _, p := range c.graphics {
+		b.WriteString(ansi.SaveCursor + "\r")
+		if up := c.Height() - 1 - p.rect.Min.Y; up > 0 {
+			b.WriteString(ansi.CursorUp(up))
+		}
+		if p.rect.Min.X > 0 {
+			b.WriteString(ansi.CursorForward(p.rect.Min.X))
+		}
+		b.WriteString(p.seq + ansi.RestoreCursor)
+	}
+	return b.String()
+}
+
+// placeGraphics places Graphics on the canvas, in the given rectangle whose
+// cells are already reserved.
+func (c *Canvas) placeGraphics(g *Graphics, rect image.Rectangle, seq string) {
+	c.graphics = append(c.graphics, graphicsPlacement{graphics: g, rect: rect, seq: seq})
+}
+
+// occlude draws the Graphics that overlap the given rectangle with half
+// blocks, since they can't be partially covered.
+func (c *Canvas) occlude(rect image.Rectangle) {
+	placements := c.graphics[:0]
+	for _, p := range c.graphics {
+		if !p.rect.Overlaps(rect) {
+			placements = append(placements, p)
+			continue
+		}
+		p.graphics.image.Draw(c.scr, p.rect)
+	}
+	c.graphics = placements
 }
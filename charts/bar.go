@@ -0,0 +1,251 @@
+package charts
+
+import (
+	"image/color"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// BarChart is a chart with a bar per value. Bars are drawn with block
+// elements, so their length has a resolution of an eighth of a cell.
+//
+//	b := charts.NewBarChart().
+//		Bar("Go", 42).
+//		Bar("Rust", 27).
+//		Bar("Zig", 9).
+//		ShowValues(true)
+//	fmt.Println(b)
+type BarChart struct {
+	labels []string
+	values []float64
+
+	orientation   Orientation
+	width, height int
+	barWidth, gap int
+
+	max    float64
+	hasMax bool
+
+	showValues bool
+	formatter  Formatter
+
+	barStyle   lipgloss.Style
+	labelStyle lipgloss.Style
+	valueStyle lipgloss.Style
+	axisStyle  lipgloss.Style
+	border     lipgloss.Border
+	gradient   []color.Color
+}
+
+// NewBarChart returns a new, empty, horizontal bar chart.
+func NewBarChart() *BarChart {
+	return &BarChart{
+		orientation: Horizontal,
+		width:       40, //nolint:mnd
+		height:      10, //nolint:mnd
+		barWidth:    1,
+		formatter:   DefaultFormatter,
+		barStyle:    lipgloss.NewStyle(),
+		labelStyle:  lipgloss.NewStyle(),
+		valueStyle:  lipgloss.NewStyle(),
+		axisStyle:   lipgloss.NewStyle(),
+		border:      lipgloss.NormalBorder(),
+	}
+}
+
+// Bar adds a bar with the given label and value. Negative values are drawn
+// as empty bars.
+func (b *BarChart) Bar(label string, value float64) *BarChart {
+	b.labels = append(b.labels, label)
+	b.values = append(b.values, value)
+	return b
+}
+
+// ClearBars removes all bars.
+func (b *BarChart) ClearBars() *BarChart {
+	b.labels, b.values = nil, nil
+	return b
+}
+
+// Orientation sets the direction the bars grow in.
+func (b *BarChart) Orientation(o Orientation) *BarChart {
+	b.orientation = o
+	return b
+}
+
+// Width sets the width of a horizontal bar chart, labels and values
+// included. Vertical bar charts are as wide as their bars.
+func (b *BarChart) Width(w int) *BarChart {
+	b.width = max(w, 0)
+	return b
+}
+
+// Height sets the height of a vertical bar chart, labels and values
+// included. Horizontal bar charts are as tall as their bars.
+func (b *BarChart) Height(h int) *BarChart {
+	b.height = max(h, 0)
+	return b
+}
+
+// BarWidth sets the thickness of the bars in cells. The default is 1.
+func (b *BarChart) BarWidth(w int) *BarChart {
+	b.barWidth = max(w, 1)
+	return b
+}
+
+// Gap sets the number of cells between bars. The default is 0.
+func (b *BarChart) Gap(gap int) *BarChart {
+	b.gap = max(gap, 0)
+	return b
+}
+
+// Max sets the value of a full-length bar. By default, it's the largest
+// value.
+func (b *BarChart) Max(v float64) *BarChart {
+	b.max, b.hasMax = v, true
+	return b
+}
+
+// ShowValues sets whether to draw the value of each bar at its end.
+func (b *BarChart) ShowValues(v bool) *BarChart {
+	b.showValues = v
+	return b
+}
+
+// Formatter sets the function used to format values.
+func (b *BarChart) Formatter(f Formatter) *BarChart {
+	if f != nil {
+		b.formatter = f
+	}
+	return b
+}
+
+// BarStyle sets the style of the bars.
+func (b *BarChart) BarStyle(style lipgloss.Style) *BarChart {
+	b.barStyle = style
+	return b
+}
+
+// LabelStyle sets the style of the labels.
+func (b *BarChart) LabelStyle(style lipgloss.Style) *BarChart {
+	b.labelStyle = style
+	return b
+}
+
+// ValueStyle sets the style of the values.
+func (b *BarChart) ValueStyle(style lipgloss.Style) *BarChart {
+	b.valueStyle = style
+	return b
+}
+
+// AxisStyle sets the style of the axis.
+func (b *BarChart) AxisStyle(style lipgloss.Style) *BarChart {
+	b.axisStyle = style
+	return b
+}
+
+// Border sets the border the axis is drawn with.
+func (b *BarChart) Border(border lipgloss.Border) *BarChart {
+	b.border = border
+	return b
+}
+
+// Gradient colors the bars, blending the given colors from the first bar to
+// the last one.
+func (b *BarChart) Gradient(stops ...color.Color) *BarChart {
+	b.gradient = stops
+	return b
+}
+
+// String returns the rendered bar chart.
+func (b *BarChart) String() string {
+	return b.Render()
+}
+
+// Render returns the rendered bar chart.
+func (b *BarChart) Render() string {
+	if len(b.values) == 0 {
+		return ""
+	}
+	_, hi, _ := valueRange(b.values)
+	if b.hasMax {
+		hi = b.max
+	}
+	if b.orientation == Vertical {
+		return b.renderVertical(hi)
+	}
+	return b.renderHorizontal(hi)
+}
+
+// renderHorizontal renders bars growing from an axis on the left.
+func (b *BarChart) renderHorizontal(hi float64) string {
+	var labelWidth, valueWidth int
+	for i, label := range b.labels {
+		labelWidth = max(labelWidth, lipgloss.Width(label))
+		if b.showValues {
+			valueWidth = max(valueWidth, lipgloss.Width(b.formatter(b.values[i]))+1)
+		}
+	}
+	axisX := 0
+	if labelWidth > 0 {
+		axisX = labelWidth + 1
+	}
+	length := max(b.width-axisX-1-valueWidth, 0)
+
+	n := len(b.values)
+	height := n*b.barWidth + (n-1)*b.gap
+	c := lipgloss.NewCanvas(b.width, height)
+	c.VerticalLine(axisX, 0, height, b.border, b.axisStyle)
+
+	styles := styles(b.barStyle, n, b.gradient)
+	for i, v := range b.values {
+		y := i * (b.barWidth + b.gap)
+		label := b.labels[i]
+		c.Text(labelWidth-lipgloss.Width(label), y, label, b.labelStyle)
+		end := drawHorizontalBar(c, axisX+1, y, b.barWidth, length, normalize(v, 0, hi), styles[i])
+		if b.showValues {
+			c.Text(end+1, y, b.formatter(v), b.valueStyle)
+		}
+	}
+	return c.Render()
+}
+
+// renderVertical renders bars growing from an axis at the bottom.
+func (b *BarChart) renderVertical(hi float64) string {
+	hasLabels := false
+	for _, label := range b.labels {
+		hasLabels = hasLabels || label != ""
+	}
+
+	axisY := b.height - 1
+	if hasLabels {
+		axisY--
+	}
+	length := axisY
+	if b.showValues {
+		length--
+	}
+	if length <= 0 {
+		return ""
+	}
+
+	n := len(b.values)
+	width := n*b.barWidth + (n-1)*b.gap
+	c := lipgloss.NewCanvas(width, b.height)
+	c.HorizontalLine(0, axisY, width, b.border, b.axisStyle)
+
+	styles := styles(b.barStyle, n, b.gradient)
+	for i, v := range b.values {
+		x := i * (b.barWidth + b.gap)
+		top := drawVerticalBar(c, x, axisY, b.barWidth, length, normalize(v, 0, hi), styles[i])
+		if b.showValues {
+			value := b.formatter(v)
+			c.Text(x+(b.barWidth-lipgloss.Width(value))/2, top-1, value, b.valueStyle) //nolint:mnd
+		}
+		if label := ansi.Truncate(b.labels[i], b.barWidth, ""); hasLabels {
+			c.Text(x+(b.barWidth-lipgloss.Width(label))/2, axisY+1, label, b.labelStyle) //nolint:mnd
+		}
+	}
+	return c.Render()
+}
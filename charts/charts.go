@@ -0,0 +1,163 @@
+// Package charts provides compact charts for terminals: sparklines, bar
+// charts, line charts and histograms. Charts are drawn on a [lipgloss.Canvas]
+// and styled with [lipgloss.Style], so they share colors, borders and color
+// profile degradation with the rest of your Lip Gloss output.
+//
+//	cpu := charts.NewSparkline(load...).
+//		Gradient(lipgloss.Color("#04B575"), lipgloss.Color("#FF5F87"))
+//
+//	fmt.Println(cpu)
+package charts
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+)
+
+// Formatter formats a value for a label.
+type Formatter func(v float64) string
+
+// DefaultFormatter formats a value with at most two decimals.
+func DefaultFormatter(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64) //nolint:mnd
+}
+
+// Orientation is the direction the bars of a [BarChart] grow in.
+type Orientation int
+
+// Orientations.
+const (
+	// Horizontal bars grow from left to right, with their labels on the left.
+	Horizontal Orientation = iota
+
+	// Vertical bars grow from bottom to top, with their labels at the bottom.
+	Vertical
+)
+
+// Block elements for partially filled cells, in eighths.
+var (
+	lowerBlocks = [9]string{" ", "▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"}
+	leftBlocks  = [9]string{" ", "▏", "▎", "▍", "▌", "▋", "▊", "▉", "█"}
+)
+
+// finite returns whether a value is neither NaN nor infinite. Charts skip
+// values that aren't finite.
+func finite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// valueRange returns the smallest and largest finite values. It returns false
+// if there are no finite values.
+func valueRange(values []float64) (lo, hi float64, ok bool) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if !finite(v) {
+			continue
+		}
+		lo, hi = min(lo, v), max(hi, v)
+		ok = true
+	}
+	return lo, hi, ok
+}
+
+// normalize maps a value in the range [lo, hi] to [0, 1]. Values that aren't
+// finite map to 0.
+func normalize(v, lo, hi float64) float64 {
+	if hi <= lo || !finite(v) {
+		return 0
+	}
+	norm := (v - lo) / (hi - lo)
+	if math.IsNaN(norm) {
+		return 0
+	}
+	return min(max(norm, 0), 1)
+}
+
+// eighths returns the number of full cells and the number of eighths of the
+// last, partial, cell of a bar of the given length, filled to norm.
+func eighths(norm float64, length int) (full, rem int) {
+	units := int(math.Round(norm * float64(length*8))) //nolint:mnd
+	return units / 8, units % 8                        //nolint:mnd
+}
+
+// styles returns n copies of the style, with their foreground colors blended
+// between the given stops. Without stops, the style is used as-is.
+func styles(style lipgloss.Style, n int, stops []color.Color) []lipgloss.Style {
+	result := make([]lipgloss.Style, n)
+	colors := lipgloss.Blend1D(n, stops...)
+	for i := range result {
+		result[i] = style
+		if i < len(colors) {
+			result[i] = style.Foreground(colors[i])
+		}
+	}
+	return result
+}
+
+// drawHorizontalBar draws a bar growing right from (x, y), thickness cells
+// tall, filled to norm of the given length. It returns the column after the
+// end of the bar.
+func drawHorizontalBar(c *lipgloss.Canvas, x, y, thickness, length int, norm float64, style lipgloss.Style) int {
+	full, rem := eighths(norm, length)
+	bar := strings.Repeat(leftBlocks[8], full)
+	if rem > 0 {
+		bar += leftBlocks[rem]
+	}
+	for i := range thickness {
+		c.Text(x, y+i, bar, style)
+	}
+	return x + lipgloss.Width(bar)
+}
+
+// drawVerticalBar draws a bar growing up from the row above bottom, width
+// cells wide, filled to norm of the given length. It returns the row of the
+// top of the bar.
+func drawVerticalBar(c *lipgloss.Canvas, x, bottom, width, length int, norm float64, style lipgloss.Style) int {
+	full, rem := eighths(norm, length)
+	top := bottom
+	for range full {
+		top--
+		c.Text(x, top, strings.Repeat(lowerBlocks[8], width), style)
+	}
+	if rem > 0 {
+		top--
+		c.Text(x, top, strings.Repeat(lowerBlocks[rem], width), style)
+	}
+	return top
+}
+
+// drawXLabels spreads labels evenly along a horizontal axis starting at x.
+// The first label is aligned to the left, the last one to the right and the
+// others are centered. Labels that would overlap the previous one are
+// skipped.
+func drawXLabels(c *lipgloss.Canvas, x, y, width int, labels []string, style lipgloss.Style) {
+	end := x - 1
+	for i, label := range labels {
+		w := lipgloss.Width(label)
+		pos := x
+		switch {
+		case len(labels) == 1:
+		case i == len(labels)-1:
+			pos = x + width - w
+		case i > 0:
+			pos = x + int(math.Round(float64(i*(width-1))/float64(len(labels)-1))) - w/2 //nolint:mnd
+		}
+		if pos <= end {
+			continue
+		}
+		c.Text(pos, y, label, style)
+		end = pos + w
+	}
+}
+
+// foreground returns the foreground color of a style, or nil if it has none.
+func foreground(style lipgloss.Style) color.Color {
+	if _, ok := style.GetForeground().(lipgloss.NoColor); ok {
+		return nil
+	}
+	return style.GetForeground()
+}
@@ -0,0 +1,171 @@
+package charts_test
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"charm.land/lipgloss/v2/charts"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/exp/golden"
+)
+
+func TestSparkline(t *testing.T) {
+	s := charts.NewSparkline(0, 1, 2, 3, 4, 5, 6, 7)
+	if got, expected := s.String(), "▁▂▃▄▅▆▇█"; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	// Only the most recent values fit.
+	s.Width(4)
+	if got, expected := s.String(), "▁▃▆█"; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	// NaNs leave gaps and the range can be fixed.
+	s = charts.NewSparkline(0, math.NaN(), 10).Max(20)
+	if got, expected := s.String(), "▁ ▅"; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestSparklineGradient(t *testing.T) {
+	s := charts.NewSparkline(0, 7).Gradient(lipgloss.Color("#ff0000"), lipgloss.Color("#0000ff"))
+	got := s.String()
+	if !strings.Contains(got, "38;2;255;0;0m▁") || !strings.Contains(got, "38;2;0;0;255m█") {
+		t.Errorf("expected the gradient to go from red to blue, got %q", got)
+	}
+}
+
+func TestBarChartHorizontal(t *testing.T) {
+	b := charts.NewBarChart().
+		Width(24).
+		Bar("Go", 40).
+		Bar("Rust", 25).
+		Bar("Zig", 3).
+		ShowValues(true)
+	golden.RequireEqual(t, []byte(b.String()))
+}
+
+func TestBarChartVertical(t *testing.T) {
+	b := charts.NewBarChart().
+		Orientation(charts.Vertical).
+		Height(8).
+		BarWidth(3).
+		Gap(1).
+		Bar("Mon", 3).
+		Bar("Tue", 7).
+		Bar("Wed", 5.5).
+		Bar("Thu", 0).
+		ShowValues(true)
+	golden.RequireEqual(t, []byte(b.String()))
+}
+
+func TestBarChartBorder(t *testing.T) {
+	b := charts.NewBarChart().
+		Width(10).
+		Border(lipgloss.ThickBorder()).
+		Bar("a", 1).
+		Bar("b", 2)
+	for _, line := range strings.Split(b.String(), "\n") {
+		if !strings.Contains(line, "┃") {
+			t.Errorf("expected the axis to use the thick border, got %q", line)
+		}
+	}
+}
+
+func TestLineChart(t *testing.T) {
+	l := charts.NewLineChart().
+		Width(30).
+		Height(8).
+		Series([]float64{0, 2, 4, 6, 8, 10, 8, 6, 4, 2, 0}, lipgloss.NewStyle()).
+		XLabels("0s", "5s", "10s")
+	golden.RequireEqual(t, []byte(l.String()))
+}
+
+func TestLineChartSeriesColors(t *testing.T) {
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
+	l := charts.NewLineChart().
+		Width(12).
+		Height(4).
+		Series([]float64{1, 1}, red)
+	got := l.String()
+	if !strings.Contains(got, "38;2;255;0;0m") {
+		t.Errorf("expected the series to be red, got %q", got)
+	}
+	if strings.Count(ansi.Strip(got), "\n") != 3 {
+		t.Errorf("expected 4 lines, got %q", got)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	h := charts.NewHistogram(1, 2, 2, 3, 3, 3, 4, 4, 5, 9).
+		Bins(4).
+		Width(14).
+		Height(6)
+
+	counts, lo, hi := h.Counts()
+	if expected := []int{3, 5, 1, 1}; !slices.Equal(counts, expected) {
+		t.Errorf("expected counts %v, got %v", expected, counts)
+	}
+	if lo != 1 || hi != 9 {
+		t.Errorf("expected range [1, 9], got [%v, %v]", lo, hi)
+	}
+	golden.RequireEqual(t, []byte(h.String()))
+}
+
+func TestEmptyCharts(t *testing.T) {
+	for name, chart := range map[string]interface{ String() string }{
+		"sparkline": charts.NewSparkline(),
+		"bar":       charts.NewBarChart(),
+		"line":      charts.NewLineChart(),
+		"histogram": charts.NewHistogram(),
+	} {
+		if got := chart.String(); got != "" {
+			t.Errorf("%s: expected an empty chart, got %q", name, got)
+		}
+	}
+}
+
+func TestNonFiniteValues(t *testing.T) {
+	inf, nan := math.Inf(1), math.NaN()
+
+	// Infinities leave gaps, like NaNs, and don't count towards the range.
+	if got, expected := charts.NewSparkline(0, inf, 7, -inf, 3).String(), "▁ █ ▄"; got != expected {
+		t.Errorf("sparkline: expected %q, got %q", expected, got)
+	}
+	if got := ansi.Strip(charts.NewSparkline(nan, -inf).String()); strings.TrimSpace(got) != "" {
+		t.Errorf("sparkline: expected no bars, got %q", got)
+	}
+
+	counts, lo, hi := charts.NewHistogram(1, inf, 2, -inf, 3, nan).Bins(2).Counts()
+	if expected := []int{1, 2}; !slices.Equal(counts, expected) || lo != 1 || hi != 3 {
+		t.Errorf("histogram: expected counts %v in [1, 3], got %v in [%v, %v]", expected, counts, lo, hi)
+	}
+	if counts, _, _ := charts.NewHistogram(nan, nan).Counts(); counts != nil {
+		t.Errorf("histogram: expected no counts, got %v", counts)
+	}
+
+	for name, chart := range map[string]interface{ String() string }{
+		"histogram": charts.NewHistogram(inf, -inf, 1, 2).Bins(2).Width(14).Height(6),
+		"line":      charts.NewLineChart().Width(10).Height(4).Series([]float64{1, inf, -inf, 2}, lipgloss.NewStyle()),
+		"bar":       charts.NewBarChart().Width(10).Bar("a", inf).Bar("b", -inf).Bar("c", 1),
+		"vertical":  charts.NewBarChart().Orientation(charts.Vertical).Height(4).Bar("a", inf).Bar("b", 1),
+	} {
+		if got := chart.String(); got == "" {
+			t.Errorf("%s: expected the finite values to be drawn", name)
+		}
+	}
+
+	for name, chart := range map[string]interface{ String() string }{
+		"histogram": charts.NewHistogram(nan, nan),
+		"line":      charts.NewLineChart().Series([]float64{nan, nan}, lipgloss.NewStyle()),
+	} {
+		if got := chart.String(); got != "" {
+			t.Errorf("%s: expected an empty chart, got %q", name, got)
+		}
+	}
+	_ = charts.NewBarChart().Bar("a", nan).String()
+}
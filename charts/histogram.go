@@ -0,0 +1,170 @@
+package charts
+
+import (
+	"image/color"
+	"strconv"
+
+	"charm.land/lipgloss/v2"
+)
+
+// Histogram is a chart of the distribution of values. The range of the values
+// is split into bins of equal size, and each bin is drawn as a vertical bar
+// as tall as the number of values in it.
+//
+//	h := charts.NewHistogram(durations...).Bins(20).Height(8)
+//	fmt.Println(h)
+type Histogram struct {
+	values []float64
+	bins   int
+
+	width, height int
+
+	formatter Formatter
+
+	barStyle   lipgloss.Style
+	labelStyle lipgloss.Style
+	axisStyle  lipgloss.Style
+	border     lipgloss.Border
+	gradient   []color.Color
+}
+
+// NewHistogram returns a new histogram of the given values.
+func NewHistogram(values ...float64) *Histogram {
+	return &Histogram{
+		values:     values,
+		bins:       10, //nolint:mnd
+		width:      40, //nolint:mnd
+		height:     10, //nolint:mnd
+		formatter:  DefaultFormatter,
+		barStyle:   lipgloss.NewStyle(),
+		labelStyle: lipgloss.NewStyle(),
+		axisStyle:  lipgloss.NewStyle(),
+		border:     lipgloss.NormalBorder(),
+	}
+}
+
+// Data replaces the values of the histogram. NaN values are ignored.
+func (h *Histogram) Data(values ...float64) *Histogram {
+	h.values = values
+	return h
+}
+
+// Bins sets the number of bins. The default is 10.
+func (h *Histogram) Bins(n int) *Histogram {
+	h.bins = max(n, 1)
+	return h
+}
+
+// Width sets the width of the histogram, labels included. The bins share the
+// width equally, so the histogram may be a bit narrower.
+func (h *Histogram) Width(w int) *Histogram {
+	h.width = max(w, 0)
+	return h
+}
+
+// Height sets the height of the histogram, labels included.
+func (h *Histogram) Height(height int) *Histogram {
+	h.height = max(height, 0)
+	return h
+}
+
+// Formatter sets the function used to format the range of the values below
+// the x axis.
+func (h *Histogram) Formatter(f Formatter) *Histogram {
+	if f != nil {
+		h.formatter = f
+	}
+	return h
+}
+
+// BarStyle sets the style of the bars.
+func (h *Histogram) BarStyle(style lipgloss.Style) *Histogram {
+	h.barStyle = style
+	return h
+}
+
+// LabelStyle sets the style of the labels.
+func (h *Histogram) LabelStyle(style lipgloss.Style) *Histogram {
+	h.labelStyle = style
+	return h
+}
+
+// AxisStyle sets the style of the axes.
+func (h *Histogram) AxisStyle(style lipgloss.Style) *Histogram {
+	h.axisStyle = style
+	return h
+}
+
+// Border sets the border the axes are drawn with.
+func (h *Histogram) Border(border lipgloss.Border) *Histogram {
+	h.border = border
+	return h
+}
+
+// Gradient colors the bars, blending the given colors from the first bin to
+// the last one.
+func (h *Histogram) Gradient(stops ...color.Color) *Histogram {
+	h.gradient = stops
+	return h
+}
+
+// Counts returns the number of values in each bin, along with the bounds of
+// the range of values.
+func (h *Histogram) Counts() (counts []int, lo, hi float64) {
+	lo, hi, ok := valueRange(h.values)
+	if !ok {
+		return nil, 0, 0
+	}
+	counts = make([]int, h.bins)
+	for _, v := range h.values {
+		if !finite(v) {
+			continue
+		}
+		bin := int(normalize(v, lo, hi) * float64(h.bins))
+		counts[min(max(bin, 0), h.bins-1)]++
+	}
+	return counts, lo, hi
+}
+
+// String returns the rendered histogram.
+func (h *Histogram) String() string {
+	return h.Render()
+}
+
+// Render returns the rendered histogram.
+func (h *Histogram) Render() string {
+	counts, lo, hi := h.Counts()
+	if counts == nil {
+		return ""
+	}
+	var most int
+	for _, n := range counts {
+		most = max(most, n)
+	}
+
+	// The y axis is labeled with the largest count and the x axis with the
+	// range of the values.
+	maxLabel := strconv.Itoa(most)
+	axisX := lipgloss.Width(maxLabel)
+	axisY := h.height - 2 //nolint:mnd
+	barWidth := (h.width - axisX - 1) / h.bins
+	if axisY <= 0 || barWidth <= 0 {
+		return ""
+	}
+	plotWidth := barWidth * h.bins
+
+	c := lipgloss.NewCanvas(axisX+1+plotWidth, h.height)
+	c.VerticalLine(axisX, 0, axisY, h.border, h.axisStyle)
+	c.HorizontalLine(axisX+1, axisY, plotWidth, h.border, h.axisStyle)
+	c.Text(axisX, axisY, h.border.BottomLeft, h.axisStyle)
+	c.Text(0, 0, maxLabel, h.labelStyle)
+	c.Text(axisX, 0, h.border.MiddleRight, h.axisStyle)
+	drawXLabels(c, axisX+1, axisY+1, plotWidth, []string{h.formatter(lo), h.formatter(hi)}, h.labelStyle)
+
+	styles := styles(h.barStyle, h.bins, h.gradient)
+	for i, n := range counts {
+		norm := normalize(float64(n), 0, float64(most))
+		drawVerticalBar(c, axisX+1+i*barWidth, axisY, barWidth, axisY, norm, styles[i])
+	}
+	return c.Render()
+}
@@ -0,0 +1,221 @@
+package charts
+
+import (
+	"image"
+	"math"
+
+	"charm.land/lipgloss/v2"
+)
+
+// LineChart is a chart with one or more series of values drawn as lines with
+// braille patterns, which have 2x4 dots per cell. Values are laid out evenly
+// along the x axis.
+//
+//	l := charts.NewLineChart().
+//		Width(60).
+//		Height(12).
+//		Series(latency, lipgloss.NewStyle().Foreground(lipgloss.Color("212"))).
+//		XLabels("-1h", "-30m", "now")
+//	fmt.Println(l)
+type LineChart struct {
+	series []series
+
+	width, height int
+
+	min, max       float64
+	hasMin, hasMax bool
+
+	xLabels   []string
+	formatter Formatter
+
+	axisStyle  lipgloss.Style
+	labelStyle lipgloss.Style
+	border     lipgloss.Border
+}
+
+// series is a series of values of a [LineChart].
+type series struct {
+	values []float64
+	style  lipgloss.Style
+}
+
+// NewLineChart returns a new, empty, line chart.
+func NewLineChart() *LineChart {
+	return &LineChart{
+		width:      40, //nolint:mnd
+		height:     10, //nolint:mnd
+		formatter:  DefaultFormatter,
+		axisStyle:  lipgloss.NewStyle(),
+		labelStyle: lipgloss.NewStyle(),
+		border:     lipgloss.NormalBorder(),
+	}
+}
+
+// Series adds a series of values, drawn in the foreground color of the given
+// style. NaN values leave a gap in the line. Where series cross, the cell
+// takes the color of the last series.
+func (l *LineChart) Series(values []float64, style lipgloss.Style) *LineChart {
+	l.series = append(l.series, series{values: values, style: style})
+	return l
+}
+
+// ClearSeries removes all series.
+func (l *LineChart) ClearSeries() *LineChart {
+	l.series = nil
+	return l
+}
+
+// Width sets the width of the chart, labels included.
+func (l *LineChart) Width(w int) *LineChart {
+	l.width = max(w, 0)
+	return l
+}
+
+// Height sets the height of the chart, labels included.
+func (l *LineChart) Height(h int) *LineChart {
+	l.height = max(h, 0)
+	return l
+}
+
+// Min sets the value at the bottom of the y axis. By default, it's the
+// smallest value of all series.
+func (l *LineChart) Min(v float64) *LineChart {
+	l.min, l.hasMin = v, true
+	return l
+}
+
+// Max sets the value at the top of the y axis. By default, it's the largest
+// value of all series.
+func (l *LineChart) Max(v float64) *LineChart {
+	l.max, l.hasMax = v, true
+	return l
+}
+
+// XLabels sets the labels below the x axis. They are spread evenly, with the
+// first one at the start of the axis and the last one at the end.
+func (l *LineChart) XLabels(labels ...string) *LineChart {
+	l.xLabels = labels
+	return l
+}
+
+// Formatter sets the function used to format the labels of the y axis.
+func (l *LineChart) Formatter(f Formatter) *LineChart {
+	if f != nil {
+		l.formatter = f
+	}
+	return l
+}
+
+// AxisStyle sets the style of the axes.
+func (l *LineChart) AxisStyle(style lipgloss.Style) *LineChart {
+	l.axisStyle = style
+	return l
+}
+
+// LabelStyle sets the style of the labels.
+func (l *LineChart) LabelStyle(style lipgloss.Style) *LineChart {
+	l.labelStyle = style
+	return l
+}
+
+// Border sets the border the axes are drawn with. The left edge is used for
+// the y axis, the top edge for the x axis, the bottom-left corner for where
+// they meet and the middle-right junction for the ticks of the y axis.
+func (l *LineChart) Border(border lipgloss.Border) *LineChart {
+	l.border = border
+	return l
+}
+
+// String returns the rendered line chart.
+func (l *LineChart) String() string {
+	return l.Render()
+}
+
+// Render returns the rendered line chart.
+func (l *LineChart) Render() string {
+	var all []float64
+	for _, s := range l.series {
+		all = append(all, s.values...)
+	}
+	lo, hi, ok := valueRange(all)
+	if !ok {
+		return ""
+	}
+	if l.hasMin {
+		lo = l.min
+	}
+	if l.hasMax {
+		hi = l.max
+	}
+
+	// The plot is above the x axis and its labels.
+	plotHeight := l.height - 1
+	if len(l.xLabels) > 0 {
+		plotHeight--
+	}
+	if plotHeight <= 0 {
+		return ""
+	}
+
+	// The y axis has labels for the top, bottom and, if there's room,
+	// middle of the range.
+	ticks := map[int]float64{0: hi, plotHeight - 1: lo}
+	if plotHeight >= 5 { //nolint:mnd
+		ticks[(plotHeight-1)/2] = hi - (hi-lo)*float64((plotHeight-1)/2)/float64(plotHeight-1) //nolint:mnd
+	}
+	var labelWidth int
+	for _, v := range ticks {
+		labelWidth = max(labelWidth, lipgloss.Width(l.formatter(v)))
+	}
+	axisX := labelWidth
+	plotWidth := l.width - axisX - 1
+	if plotWidth <= 0 {
+		return ""
+	}
+
+	c := lipgloss.NewCanvas(l.width, l.height)
+	c.VerticalLine(axisX, 0, plotHeight, l.border, l.axisStyle)
+	c.HorizontalLine(axisX+1, plotHeight, plotWidth, l.border, l.axisStyle)
+	c.Text(axisX, plotHeight, l.border.BottomLeft, l.axisStyle)
+	for y, v := range ticks {
+		label := l.formatter(v)
+		c.Text(axisX-lipgloss.Width(label), y, label, l.labelStyle)
+		c.Text(axisX, y, l.border.MiddleRight, l.axisStyle)
+	}
+	if len(l.xLabels) > 0 {
+		drawXLabels(c, axisX+1, plotHeight+1, plotWidth, l.xLabels, l.labelStyle)
+	}
+
+	grid := lipgloss.NewPixelGrid(plotWidth, plotHeight, lipgloss.BraillePixels)
+	for _, s := range l.series {
+		l.plot(grid, s, lo, hi)
+	}
+	grid.Draw(c, image.Rect(axisX+1, 0, l.width, plotHeight))
+
+	return c.Render()
+}
+
+// plot draws a series onto the pixel grid.
+func (l *LineChart) plot(grid *lipgloss.PixelGrid, s series, lo, hi float64) {
+	fg := foreground(s.style)
+	n := len(s.values)
+	prev, hasPrev := image.Point{}, false
+	for i, v := range s.values {
+		if !finite(v) {
+			hasPrev = false
+			continue
+		}
+		var x int
+		if n > 1 {
+			x = int(math.Round(float64(i*(grid.Width()-1)) / float64(n-1)))
+		}
+		y := int(math.Round((1 - normalize(v, lo, hi)) * float64(grid.Height()-1)))
+		pt := image.Pt(x, y)
+		if hasPrev {
+			grid.Line(prev.X, prev.Y, pt.X, pt.Y, fg)
+		} else {
+			grid.Set(pt.X, pt.Y, fg)
+		}
+		prev, hasPrev = pt, true
+	}
+}
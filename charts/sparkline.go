@@ -0,0 +1,114 @@
+package charts
+
+import (
+	"image/color"
+	"math"
+
+	"charm.land/lipgloss/v2"
+)
+
+// Sparkline is a chart that fits on a single line, with one block element per
+// value: ▁▂▃▄▅▆▇█.
+//
+//	s := charts.NewSparkline(3, 5, 2, 8, 4).Width(20)
+//	fmt.Println(s)
+type Sparkline struct {
+	data  []float64
+	width int
+
+	min, max       float64
+	hasMin, hasMax bool
+
+	style    lipgloss.Style
+	gradient []color.Color
+}
+
+// NewSparkline returns a new sparkline with the given values.
+func NewSparkline(data ...float64) *Sparkline {
+	return &Sparkline{data: data, style: lipgloss.NewStyle()}
+}
+
+// Data replaces the values of the sparkline. NaN values are drawn as blanks.
+func (s *Sparkline) Data(data ...float64) *Sparkline {
+	s.data = data
+	return s
+}
+
+// Push appends values to the sparkline. Combined with [Sparkline.Width],
+// this makes for a chart that scrolls as new values come in.
+func (s *Sparkline) Push(values ...float64) *Sparkline {
+	s.data = append(s.data, values...)
+	return s
+}
+
+// Width sets the maximum width of the sparkline. When there are more values
+// than fit, only the most recent ones are shown. A width of 0 shows all
+// values.
+func (s *Sparkline) Width(w int) *Sparkline {
+	s.width = max(w, 0)
+	return s
+}
+
+// Min sets the value drawn as the lowest block. By default, it's the
+// smallest value shown.
+func (s *Sparkline) Min(v float64) *Sparkline {
+	s.min, s.hasMin = v, true
+	return s
+}
+
+// Max sets the value drawn as the highest block. By default, it's the
+// largest value shown.
+func (s *Sparkline) Max(v float64) *Sparkline {
+	s.max, s.hasMax = v, true
+	return s
+}
+
+// Style sets the style of the sparkline.
+func (s *Sparkline) Style(style lipgloss.Style) *Sparkline {
+	s.style = style
+	return s
+}
+
+// Gradient colors the blocks by their height, blending the given colors from
+// the lowest block to the highest one.
+func (s *Sparkline) Gradient(stops ...color.Color) *Sparkline {
+	s.gradient = stops
+	return s
+}
+
+// String returns the rendered sparkline.
+func (s *Sparkline) String() string {
+	return s.Render()
+}
+
+// Render returns the rendered sparkline.
+func (s *Sparkline) Render() string {
+	data := s.data
+	if s.width > 0 && len(data) > s.width {
+		data = data[len(data)-s.width:]
+	}
+	if len(data) == 0 {
+		return ""
+	}
+
+	lo, hi, _ := valueRange(data)
+	if s.hasMin {
+		lo = s.min
+	}
+	if s.hasMax {
+		hi = s.max
+	}
+
+	levels := len(lowerBlocks) - 1
+	styles := styles(s.style, levels, s.gradient)
+	c := lipgloss.NewCanvas(len(data), 1)
+	for x, v := range data {
+		if !finite(v) {
+			continue
+		}
+		level := int(math.Round(normalize(v, lo, hi) * float64(levels-1)))
+		level = min(max(level, 0), levels-1)
+		c.Text(x, 0, lowerBlocks[level+1], styles[level])
+	}
+	return c.Render()
+}
@@ -0,0 +1,457 @@
+package lipgloss
+
+import (
+	"image"
+	"image/color"
+
+	uv "github.com/charmbracelet/ultraviolet"
+)
+
+// HorizontalLine draws a horizontal line of the given length starting at
+// (x, y), using the top edge of the given [Border]. Where the line crosses
+// other box-drawing characters, the junctions are merged, so crossing a
+// vertical line draws a "┼".
+func (c *Canvas) HorizontalLine(x, y, length int, border Border, style Style) *Canvas {
+	for i := range length {
+		// The ends of the line don't extend past it, unless the line is a
+		// single cell, which is drawn whole.
+		var trim boxJunction
+		if i == 0 && length > 1 {
+			trim[3] = 1
+		}
+		if i == length-1 && length > 1 {
+			trim[1] = 1
+		}
+		c.setGlyph(x+i, y, border.Top, style, trim)
+	}
+	return c
+}
+
+// VerticalLine draws a vertical line of the given length starting at (x, y),
+// using the left edge of the given [Border]. Junctions are merged like in
+// [Canvas.HorizontalLine].
+func (c *Canvas) VerticalLine(x, y, length int, border Border, style Style) *Canvas {
+	for i := range length {
+		var trim boxJunction
+		if i == 0 && length > 1 {
+			trim[0] = 1
+		}
+		if i == length-1 && length > 1 {
+			trim[2] = 1
+		}
+		c.setGlyph(x, y+i, border.Left, style, trim)
+	}
+	return c
+}
+
+// Rect draws the outline of a rectangle using the given [Border]. Junctions
+// with other box-drawing characters are merged, so adjacent and overlapping
+// rectangles share their edges.
+func (c *Canvas) Rect(rect image.Rectangle, border Border, style Style) *Canvas {
+	rect = rect.Canon()
+	if rect.Empty() {
+		return c
+	}
+	minX, minY := rect.Min.X, rect.Min.Y
+	maxX, maxY := rect.Max.X-1, rect.Max.Y-1
+
+	for x := minX + 1; x < maxX; x++ {
+		c.setGlyph(x, minY, border.Top, style, boxJunction{})
+		c.setGlyph(x, maxY, border.Bottom, style, boxJunction{})
+	}
+	for y := minY + 1; y < maxY; y++ {
+		c.setGlyph(minX, y, border.Left, style, boxJunction{})
+		c.setGlyph(maxX, y, border.Right, style, boxJunction{})
+	}
+
+	switch {
+	case rect.Dx() == 1 && rect.Dy() == 1:
+		c.setGlyph(minX, minY, border.TopLeft, style, boxJunction{})
+	case rect.Dy() == 1:
+		c.HorizontalLine(minX, minY, rect.Dx(), border, style)
+	case rect.Dx() == 1:
+		c.VerticalLine(minX, minY, rect.Dy(), border, style)
+	default:
+		c.setGlyph(minX, minY, border.TopLeft, style, boxJunction{})
+		c.setGlyph(maxX, minY, border.TopRight, style, boxJunction{})
+		c.setGlyph(minX, maxY, border.BottomLeft, style, boxJunction{})
+		c.setGlyph(maxX, maxY, border.BottomRight, style, boxJunction{})
+	}
+	return c
+}
+
+// Box draws a rectangle like [Canvas.Rect] and fills its inside with the
+// given [Style].
+func (c *Canvas) Box(rect image.Rectangle, border Border, style Style) *Canvas {
+	rect = rect.Canon()
+	c.Fill(rect.Inset(1), style)
+	return c.Rect(rect, border, style)
+}
+
+// Fill fills the given area with blank cells in the given [Style]. Usually,
+// only the background color of the style is visible.
+func (c *Canvas) Fill(rect image.Rectangle, style Style) *Canvas {
+	cell := uv.EmptyCell
+	cell.Style = cellStyle(style)
+	rect = rect.Canon().Intersect(c.Bounds())
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			c.SetCell(x, y, &cell)
+		}
+	}
+	return c
+}
+
+// Text draws a string at (x, y) rendered with the given [Style]. The string
+// may span multiple lines and contain ANSI escape sequences. Anything outside
+// of the canvas is clipped.
+func (c *Canvas) Text(x, y int, text string, style Style) *Canvas {
+	text = style.Render(text)
+	return c.drawText(image.Rect(x, y, x+Width(text), y+Height(text)), text)
+}
+
+// TextRect draws a string rendered with the given [Style] in the given area,
+// starting at its top-left corner. Anything outside of the area is clipped.
+func (c *Canvas) TextRect(rect image.Rectangle, text string, style Style) *Canvas {
+	return c.drawText(rect, style.Render(text))
+}
+
+// drawText draws an already rendered string in the given area, starting at
+// its top-left corner.
+func (c *Canvas) drawText(rect image.Rectangle, text string) *Canvas {
+	rect = rect.Canon()
+	area := image.Rectangle{
+		Min: rect.Min,
+		Max: rect.Min.Add(image.Pt(Width(text), Height(text))),
+	}.Intersect(rect)
+	if area.Empty() {
+		return c
+	}
+	ss := uv.NewStyledString(text)
+	ss.Draw(clipScreen{c, c.Bounds()}, area)
+	return c
+}
+
+// Copy copies the cells in the given area to dst, the top-left corner of the
+// destination area. The areas may overlap.
+func (c *Canvas) Copy(src image.Rectangle, dst image.Point) *Canvas {
+	src = src.Canon().Intersect(c.Bounds())
+	if src.Empty() {
+		return c
+	}
+	buf := c.scr.CloneArea(src)
+	if buf == nil {
+		return c
+	}
+	buf.Draw(clipScreen{c, c.Bounds()}, image.Rectangle{
+		Min: dst,
+		Max: dst.Add(src.Size()),
+	})
+	return c
+}
+
+// Move moves the cells in the given area to dst, the top-left corner of the
+// destination area. Cells left behind are cleared.
+func (c *Canvas) Move(src image.Rectangle, dst image.Point) *Canvas {
+	src = src.Canon().Intersect(c.Bounds())
+	if src.Empty() {
+		return c
+	}
+	buf := c.scr.CloneArea(src)
+	if buf == nil {
+		return c
+	}
+	c.scr.ClearArea(src)
+	buf.Draw(clipScreen{c, c.Bounds()}, image.Rectangle{
+		Min: dst,
+		Max: dst.Add(src.Size()),
+	})
+	return c
+}
+
+// Scroll scrolls the cells in the given area by dx columns and dy rows.
+// Positive values scroll the content right and down. Cells scrolled out of
+// the area are discarded, and exposed cells are cleared.
+func (c *Canvas) Scroll(rect image.Rectangle, dx, dy int) *Canvas {
+	rect = rect.Canon().Intersect(c.Bounds())
+	if rect.Empty() {
+		return c
+	}
+	buf := c.scr.CloneArea(rect)
+	if buf == nil {
+		return c
+	}
+	c.scr.ClearArea(rect)
+	buf.Draw(clipScreen{c, rect}, rect.Add(image.Pt(dx, dy)))
+	return c
+}
+
+// setGlyph sets the first grapheme of the given border glyph at (x, y),
+// merging it with any box-drawing character already there. The directions
+// set in trim are not merged, which is used for the ends of lines so they
+// don't poke through the lines they end on.
+func (c *Canvas) setGlyph(x, y int, glyph string, style Style, trim boxJunction) {
+	glyph = getFirstRuneAsString(glyph)
+	if glyph == "" {
+		return
+	}
+	if prev := c.CellAt(x, y); prev != nil {
+		glyph = mergeJunction(prev.Content, glyph, trim)
+	}
+	cell := uv.NewCell(c.WidthMethod(), glyph)
+	cell.Style = cellStyle(style)
+	link, params := style.GetHyperlink()
+	cell.Link = uv.NewLink(link, params)
+	c.SetCell(x, y, cell)
+}
+
+// cellStyle converts a [Style] into the style of a single cell. Only colors
+// and text attributes are taken into account.
+func cellStyle(s Style) uv.Style {
+	var attrs uint8
+	if s.GetBold() {
+		attrs |= uv.AttrBold
+	}
+	if s.GetFaint() {
+		attrs |= uv.AttrFaint
+	}
+	if s.GetItalic() {
+		attrs |= uv.AttrItalic
+	}
+	if s.GetBlink() {
+		attrs |= uv.AttrBlink
+	}
+	if s.GetReverse() {
+		attrs |= uv.AttrReverse
+	}
+	if s.GetStrikethrough() {
+		attrs |= uv.AttrStrikethrough
+	}
+
+	var ul Underline
+	if s.GetUnderline() {
+		ul = s.GetUnderlineStyle()
+	}
+
+	return uv.Style{
+		Fg:             cellColor(s.GetForeground()),
+		Bg:             cellColor(s.GetBackground()),
+		UnderlineColor: cellColor(s.GetUnderlineColor()),
+		Underline:      ul,
+		Attrs:          attrs,
+	}
+}
+
+// cellColor converts [NoColor] to nil, which is how cells represent the
+// absence of a color.
+func cellColor(c color.Color) color.Color {
+	if _, ok := c.(NoColor); ok {
+		return nil
+	}
+	return c
+}
+
+// boxJunction describes the lines going out of a box-drawing character in
+// the up, right, down and left directions. Each value is the weight of the
+// line: 0 for none, 1 for light, 2 for heavy and 3 for double.
+type boxJunction [4]uint8
+
+// boxJunctions maps box-drawing characters to their junctions.
+var boxJunctions = map[rune]boxJunction{
+	'─': {0, 1, 0, 1},
+	'━': {0, 2, 0, 2},
+	'│': {1, 0, 1, 0},
+	'┃': {2, 0, 2, 0},
+	'┌': {0, 1, 1, 0},
+	'┍': {0, 2, 1, 0},
+	'┎': {0, 1, 2, 0},
+	'┏': {0, 2, 2, 0},
+	'┐': {0, 0, 1, 1},
+	'┑': {0, 0, 1, 2},
+	'┒': {0, 0, 2, 1},
+	'┓': {0, 0, 2, 2},
+	'└': {1, 1, 0, 0},
+	'┕': {1, 2, 0, 0},
+	'┖': {2, 1, 0, 0},
+	'┗': {2, 2, 0, 0},
+	'┘': {1, 0, 0, 1},
+	'┙': {1, 0, 0, 2},
+	'┚': {2, 0, 0, 1},
+	'┛': {2, 0, 0, 2},
+	'├': {1, 1, 1, 0},
+	'┝': {1, 2, 1, 0},
+	'┞': {2, 1, 1, 0},
+	'┟': {1, 1, 2, 0},
+	'┠': {2, 1, 2, 0},
+	'┡': {2, 2, 1, 0},
+	'┢': {1, 2, 2, 0},
+	'┣': {2, 2, 2, 0},
+	'┤': {1, 0, 1, 1},
+	'┥': {1, 0, 1, 2},
+	'┦': {2, 0, 1, 1},
+	'┧': {1, 0, 2, 1},
+	'┨': {2, 0, 2, 1},
+	'┩': {2, 0, 1, 2},
+	'┪': {1, 0, 2, 2},
+	'┫': {2, 0, 2, 2},
+	'┬': {0, 1, 1, 1},
+	'┭': {0, 1, 1, 2},
+	'┮': {0, 2, 1, 1},
+	'┯': {0, 2, 1, 2},
+	'┰': {0, 1, 2, 1},
+	'┱': {0, 1, 2, 2},
+	'┲': {0, 2, 2, 1},
+	'┳': {0, 2, 2, 2},
+	'┴': {1, 1, 0, 1},
+	'┵': {1, 1, 0, 2},
+	'┶': {1, 2, 0, 1},
+	'┷': {1, 2, 0, 2},
+	'┸': {2, 1, 0, 1},
+	'┹': {2, 1, 0, 2},
+	'┺': {2, 2, 0, 1},
+	'┻': {2, 2, 0, 2},
+	'┼': {1, 1, 1, 1},
+	'┽': {1, 1, 1, 2},
+	'┾': {1, 2, 1, 1},
+	'┿': {1, 2, 1, 2},
+	'╀': {2, 1, 1, 1},
+	'╁': {1, 1, 2, 1},
+	'╂': {2, 1, 2, 1},
+	'╃': {2, 1, 1, 2},
+	'╄': {2, 2, 1, 1},
+	'╅': {1, 1, 2, 2},
+	'╆': {1, 2, 2, 1},
+	'╇': {2, 2, 1, 2},
+	'╈': {1, 2, 2, 2},
+	'╉': {2, 1, 2, 2},
+	'╊': {2, 2, 2, 1},
+	'╋': {2, 2, 2, 2},
+	'═': {0, 3, 0, 3},
+	'║': {3, 0, 3, 0},
+	'╒': {0, 3, 1, 0},
+	'╓': {0, 1, 3, 0},
+	'╔': {0, 3, 3, 0},
+	'╕': {0, 0, 1, 3},
+	'╖': {0, 0, 3, 1},
+	'╗': {0, 0, 3, 3},
+	'╘': {1, 3, 0, 0},
+	'╙': {3, 1, 0, 0},
+	'╚': {3, 3, 0, 0},
+	'╛': {1, 0, 0, 3},
+	'╜': {3, 0, 0, 1},
+	'╝': {3, 0, 0, 3},
+	'╞': {1, 3, 1, 0},
+	'╟': {3, 1, 3, 0},
+	'╠': {3, 3, 3, 0},
+	'╡': {1, 0, 1, 3},
+	'╢': {3, 0, 3, 1},
+	'╣': {3, 0, 3, 3},
+	'╤': {0, 3, 1, 3},
+	'╥': {0, 1, 3, 1},
+	'╦': {0, 3, 3, 3},
+	'╧': {1, 3, 0, 3},
+	'╨': {3, 1, 0, 1},
+	'╩': {3, 3, 0, 3},
+	'╪': {1, 3, 1, 3},
+	'╫': {3, 1, 3, 1},
+	'╬': {3, 3, 3, 3},
+	'╭': {0, 1, 1, 0},
+	'╮': {0, 0, 1, 1},
+	'╯': {1, 0, 0, 1},
+	'╰': {1, 1, 0, 0},
+	'╴': {0, 0, 0, 1},
+	'╵': {1, 0, 0, 0},
+	'╶': {0, 1, 0, 0},
+	'╷': {0, 0, 1, 0},
+	'╸': {0, 0, 0, 2},
+	'╹': {2, 0, 0, 0},
+	'╺': {0, 2, 0, 0},
+	'╻': {0, 0, 2, 0},
+	'╼': {0, 2, 0, 1},
+	'╽': {1, 0, 2, 0},
+	'╾': {0, 1, 0, 2},
+	'╿': {2, 0, 1, 0},
+}
+
+// boxGlyphs maps junctions back to box-drawing characters. Square corners are
+// preferred over rounded ones.
+var boxGlyphs = func() map[boxJunction]rune {
+	glyphs := make(map[boxJunction]rune, len(boxJunctions))
+	for r, j := range boxJunctions {
+		if prev, ok := glyphs[j]; ok && prev < r {
+			continue
+		}
+		glyphs[j] = r
+	}
+	return glyphs
+}()
+
+// asciiJunctions maps ASCII line characters to their junctions.
+var asciiJunctions = map[rune]boxJunction{
+	'-': {0, 1, 0, 1},
+	'|': {1, 0, 1, 0},
+	'+': {1, 1, 1, 1},
+}
+
+// mergeJunction returns the character to draw when glyph is drawn over prev.
+// If both are box-drawing characters, the lines of both are combined, using
+// the weights of glyph where they overlap and ignoring the lines of glyph in
+// the directions set in trim. Otherwise, glyph is returned.
+func mergeJunction(prev, glyph string, trim boxJunction) string {
+	pr, gr := []rune(prev), []rune(glyph)
+	if len(pr) != 1 || len(gr) != 1 {
+		return glyph
+	}
+
+	if pj, ok := asciiJunctions[pr[0]]; ok {
+		if gj, ok := asciiJunctions[gr[0]]; ok {
+			j := pj.merge(gj.trim(trim))
+			switch {
+			case j == asciiJunctions['-'], j == asciiJunctions['|']:
+				return glyph
+			default:
+				return "+"
+			}
+		}
+		return glyph
+	}
+
+	pj, ok := boxJunctions[pr[0]]
+	if !ok {
+		return glyph
+	}
+	gj, ok := boxJunctions[gr[0]]
+	if !ok {
+		return glyph
+	}
+	gj = gj.trim(trim)
+	if pj.merge(gj) == gj {
+		// Nothing to merge, so keep the glyph as is, rounded corners and
+		// all.
+		return glyph
+	}
+	if r, ok := boxGlyphs[pj.merge(gj)]; ok {
+		return string(r)
+	}
+	return glyph
+}
+
+// trim removes the lines in the directions set in t.
+func (j boxJunction) trim(t boxJunction) boxJunction {
+	for i, w := range t {
+		if w != 0 {
+			j[i] = 0
+		}
+	}
+	return j
+}
+
+// merge combines two junctions, preferring the weights of o.
+func (j boxJunction) merge(o boxJunction) boxJunction {
+	for i, w := range o {
+		if w != 0 {
+			j[i] = w
+		}
+	}
+	return j
+}
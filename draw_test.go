@@ -0,0 +1,197 @@
+package lipgloss
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestCanvasLines(t *testing.T) {
+	c := NewCanvas(5, 3)
+	c.VerticalLine(2, 0, 3, NormalBorder(), NewStyle())
+	c.VerticalLine(4, 0, 3, ThickBorder(), NewStyle())
+	c.HorizontalLine(0, 1, 5, NormalBorder(), NewStyle())
+
+	expected := strings.Join([]string{
+		"  │ ┃",
+		"──┼─┨",
+		"  │ ┃",
+	}, "\n")
+	if got := c.Render(); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestCanvasSingleCellLines(t *testing.T) {
+	// A single cell line crossing a line merges with it.
+	c := NewCanvas(3, 3)
+	c.VerticalLine(1, 0, 3, NormalBorder(), NewStyle())
+	c.HorizontalLine(1, 1, 1, NormalBorder(), NewStyle())
+	c.HorizontalLine(0, 2, 3, NormalBorder(), NewStyle())
+	c.VerticalLine(0, 2, 1, ThickBorder(), NewStyle())
+
+	expected := strings.Join([]string{
+		" │",
+		" ┼",
+		"╂┼─",
+	}, "\n")
+	if got := c.Render(); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestCanvasRect(t *testing.T) {
+	c := NewCanvas(7, 4)
+	c.Rect(image.Rect(0, 0, 4, 4), NormalBorder(), NewStyle())
+	c.Rect(image.Rect(3, 0, 7, 3), NormalBorder(), NewStyle())
+
+	expected := strings.Join([]string{
+		"┌──┬──┐",
+		"│  │  │",
+		"│  ├──┘",
+		"└──┘",
+	}, "\n")
+	if got := c.Render(); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	// Drawing the same rectangle again keeps rounded corners.
+	c = NewCanvas(3, 3)
+	c.Rect(image.Rect(0, 0, 3, 3), RoundedBorder(), NewStyle())
+	c.Rect(image.Rect(0, 0, 3, 3), RoundedBorder(), NewStyle())
+	expected = strings.Join([]string{
+		"╭─╮",
+		"│ │",
+		"╰─╯",
+	}, "\n")
+	if got := c.Render(); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	// Double lines crossing single lines.
+	c = NewCanvas(3, 3)
+	c.Rect(image.Rect(0, 0, 3, 3), DoubleBorder(), NewStyle())
+	c.VerticalLine(1, 0, 3, NormalBorder(), NewStyle())
+	expected = strings.Join([]string{
+		"╔╤╗",
+		"║│║",
+		"╚╧╝",
+	}, "\n")
+	if got := c.Render(); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	// ASCII lines are merged too.
+	c = NewCanvas(3, 3)
+	c.HorizontalLine(0, 1, 3, ASCIIBorder(), NewStyle())
+	c.VerticalLine(1, 0, 3, ASCIIBorder(), NewStyle())
+	expected = strings.Join([]string{
+		" |",
+		"-+-",
+		" |",
+	}, "\n")
+	if got := c.Render(); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestCanvasBox(t *testing.T) {
+	bg := color.RGBA{R: 0xff, A: 0xff}
+	c := NewCanvas(4, 3)
+	c.Box(image.Rect(0, 0, 4, 3), NormalBorder(), NewStyle().Background(bg))
+
+	for _, pt := range []image.Point{{0, 0}, {1, 1}, {3, 2}} {
+		if got := c.CellAt(pt.X, pt.Y).Style.Bg; got != bg {
+			t.Errorf("expected background %v at %v, got %v", bg, pt, got)
+		}
+	}
+	if got := c.CellAt(1, 1).Content; got != " " {
+		t.Errorf("expected blank cell, got %q", got)
+	}
+}
+
+func TestCanvasFill(t *testing.T) {
+	bg := color.RGBA{B: 0xff, A: 0xff}
+	c := NewCanvas(3, 2)
+	c.Fill(image.Rect(1, 0, 5, 1), NewStyle().Background(bg))
+
+	if got := c.CellAt(0, 0).Style.Bg; got != nil {
+		t.Errorf("expected no background, got %v", got)
+	}
+	if got := c.CellAt(2, 0).Style.Bg; got != bg {
+		t.Errorf("expected background %v, got %v", bg, got)
+	}
+	if got := c.CellAt(1, 1).Style.Bg; got != nil {
+		t.Errorf("expected no background, got %v", got)
+	}
+}
+
+func TestCanvasText(t *testing.T) {
+	c := NewCanvas(5, 2)
+	c.Fill(c.Bounds(), NewStyle())
+	c.Text(3, 0, "hello\nhi", NewStyle().Bold(true))
+	c.TextRect(image.Rect(0, 1, 2, 2), "abc", NewStyle())
+
+	expected := strings.Join([]string{
+		"   he",
+		"ab hi",
+	}, "\n")
+	if got := ansi.Strip(c.Render()); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+	if attrs := c.CellAt(3, 0).Style.Attrs; attrs != uv.AttrBold {
+		t.Errorf("expected bold text, got attributes %d", attrs)
+	}
+}
+
+func TestCanvasTextRenderedOnce(t *testing.T) {
+	// Rendering the text twice would expand the tab the style keeps.
+	style := NewStyle().TabWidth(NoTabConversion)
+	c := NewCanvas(6, 1).Text(0, 0, "a\tb", style)
+	expected := NewCanvas(6, 1).TextRect(image.Rect(0, 0, 6, 1), "a\tb", style)
+	if got, want := c.Render(), expected.Render(); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestCanvasCopyMoveScroll(t *testing.T) {
+	newCanvas := func() *Canvas {
+		c := NewCanvas(4, 3)
+		c.Text(0, 0, "ab\ncd", NewStyle())
+		return c
+	}
+
+	c := newCanvas().Copy(image.Rect(0, 0, 2, 2), image.Pt(1, 1))
+	expected := strings.Join([]string{
+		"ab",
+		"cab",
+		" cd",
+	}, "\n")
+	if got := c.Render(); got != expected {
+		t.Errorf("copy: expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	c = newCanvas().Move(image.Rect(0, 0, 2, 2), image.Pt(2, 1))
+	expected = strings.Join([]string{
+		"",
+		"  ab",
+		"  cd",
+	}, "\n")
+	if got := c.Render(); got != expected {
+		t.Errorf("move: expected:\n%q\ngot:\n%q", expected, got)
+	}
+
+	c = newCanvas().Scroll(image.Rect(0, 0, 2, 3), 0, -1)
+	expected = strings.Join([]string{
+		"cd",
+		"",
+		"",
+	}, "\n")
+	if got := c.Render(); got != expected {
+		t.Errorf("scroll: expected:\n%q\ngot:\n%q", expected, got)
+	}
+}
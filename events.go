@@ -0,0 +1,225 @@
+package lipgloss
+
+import (
+	"image"
+	"slices"
+
+	uv "github.com/charmbracelet/ultraviolet"
+)
+
+// LayerEventType is the type of a [LayerEvent].
+type LayerEventType int
+
+// Layer event types.
+const (
+	// LayerClick is sent when a mouse button is pressed over a layer. It
+	// bubbles up to the layer's parents.
+	LayerClick LayerEventType = iota
+
+	// LayerScroll is sent when the mouse wheel is used over a layer. It
+	// bubbles up to the layer's parents.
+	LayerScroll
+
+	// LayerMouseEnter is sent when the mouse enters a layer or one of its
+	// descendants. It doesn't bubble.
+	LayerMouseEnter
+
+	// LayerMouseLeave is sent when the mouse leaves a layer and all of its
+	// descendants. It doesn't bubble.
+	LayerMouseLeave
+)
+
+// String returns a string representation of the event type.
+func (t LayerEventType) String() string {
+	switch t {
+	case LayerClick:
+		return "click"
+	case LayerScroll:
+		return "scroll"
+	case LayerMouseEnter:
+		return "mouseenter"
+	case LayerMouseLeave:
+		return "mouseleave"
+	default:
+		return "unknown"
+	}
+}
+
+// LayerEvent is a mouse event routed to a [Layer] by a [Compositor].
+type LayerEvent struct {
+	// Type is the type of the event.
+	Type LayerEventType
+
+	// Mouse is the mouse event as it was passed to
+	// [Compositor.HandleMouse].
+	Mouse uv.Mouse
+
+	// Target is the top-most layer under the mouse, which is where the event
+	// originated. For [LayerMouseLeave] events, it's the top-most layer that
+	// was under the mouse before it moved.
+	Target LayerHit
+
+	// Current is the layer whose handler is being called. While the event
+	// bubbles up, this is one of the target's ancestors.
+	Current LayerHit
+
+	stopped bool
+}
+
+// StopPropagation prevents the event from bubbling up to further parents.
+func (e *LayerEvent) StopPropagation() {
+	e.stopped = true
+}
+
+// LayerEventHandler handles a [LayerEvent].
+type LayerEventHandler func(e *LayerEvent)
+
+// On registers a handler for the given event type on the layer with the given
+// ID. Only one handler per ID and event type is kept; registering another one
+// replaces it. Handlers survive [Compositor.Refresh], so they can be
+// registered for layers that are added later.
+func (c *Compositor) On(id string, typ LayerEventType, handler LayerEventHandler) *Compositor {
+	if id == "" || handler == nil {
+		return c
+	}
+	if c.handlers == nil {
+		c.handlers = make(map[string]map[LayerEventType]LayerEventHandler)
+	}
+	if c.handlers[id] == nil {
+		c.handlers[id] = make(map[LayerEventType]LayerEventHandler)
+	}
+	c.handlers[id][typ] = handler
+	return c
+}
+
+// Off removes the handler for the given event type from the layer with the
+// given ID.
+func (c *Compositor) Off(id string, typ LayerEventType) *Compositor {
+	delete(c.handlers[id], typ)
+	return c
+}
+
+// HandleMouse routes a mouse event to the layers under the mouse and reports
+// whether any handler was called.
+//
+// Clicks and wheel events are sent to the top-most layer under the mouse and
+// bubble up to its parents until a handler calls
+// [LayerEvent.StopPropagation]. Motion events are used to send
+// [LayerMouseEnter] and [LayerMouseLeave] events.
+//
+// Release events are ignored. Mouse coordinates are interpreted as in
+// [Compositor.Hit].
+func (c *Compositor) HandleMouse(ev uv.MouseEvent) bool {
+	c.update()
+	switch ev := ev.(type) {
+	case uv.MouseClickEvent:
+		return c.dispatch(LayerClick, ev.Mouse())
+	case uv.MouseWheelEvent:
+		return c.dispatch(LayerScroll, ev.Mouse())
+	case uv.MouseMotionEvent:
+		return c.hover(ev.Mouse())
+	}
+	return false
+}
+
+// target returns the top-most layer under the given point.
+func (c *Compositor) target(pt image.Point) (compositeLayer, bool) {
+	for i := len(c.layers) - 1; i >= 0; i-- {
+		if cl := c.layers[i]; pt.In(cl.visible) {
+			return cl, true
+		}
+	}
+	return compositeLayer{}, false
+}
+
+// path returns the given layer followed by all of its ancestors.
+func (c *Compositor) path(layer *Layer) []*Layer {
+	var path []*Layer
+	for layer != nil {
+		path = append(path, layer)
+		layer = c.lookup[layer].parent
+	}
+	return path
+}
+
+// dispatch sends a bubbling event to the top-most layer under the mouse and
+// its ancestors.
+func (c *Compositor) dispatch(typ LayerEventType, m uv.Mouse) bool {
+	pt := c.scenePoint(m.X, m.Y)
+	target, ok := c.target(pt)
+	if !ok {
+		return false
+	}
+
+	e := &LayerEvent{
+		Type:   typ,
+		Mouse:  m,
+		Target: target.hit(pt),
+	}
+	var handled bool
+	for _, layer := range c.path(target.layer) {
+		if c.call(layer, e, pt) {
+			handled = true
+		}
+		if e.stopped {
+			break
+		}
+	}
+	return handled
+}
+
+// hover sends enter and leave events to the layers the mouse moved in and
+// out of.
+func (c *Compositor) hover(m uv.Mouse) bool {
+	pt := c.scenePoint(m.X, m.Y)
+	var hovered []*Layer
+	var targetHit LayerHit
+	if target, ok := c.target(pt); ok {
+		hovered = c.path(target.layer)
+		targetHit = target.hit(pt)
+	}
+
+	var handled bool
+
+	// Leave events are sent from the innermost layer outwards.
+	for _, layer := range c.hovered {
+		if !slices.Contains(hovered, layer) {
+			e := &LayerEvent{Type: LayerMouseLeave, Mouse: m, Target: c.hoverHit}
+			if c.call(layer, e, pt) {
+				handled = true
+			}
+		}
+	}
+
+	// Enter events are sent from the outermost layer inwards.
+	for i := len(hovered) - 1; i >= 0; i-- {
+		if layer := hovered[i]; !slices.Contains(c.hovered, layer) {
+			e := &LayerEvent{Type: LayerMouseEnter, Mouse: m, Target: targetHit}
+			if c.call(layer, e, pt) {
+				handled = true
+			}
+		}
+	}
+
+	c.hovered, c.hoverHit = hovered, targetHit
+	return handled
+}
+
+// call calls the handler registered on the given layer for the event, if
+// any, and reports whether it was called.
+func (c *Compositor) call(layer *Layer, e *LayerEvent, pt image.Point) bool {
+	if layer.id == "" {
+		return false
+	}
+	handler, ok := c.handlers[layer.id][e.Type]
+	if !ok {
+		return false
+	}
+	if cl, ok := c.lookup[layer]; ok {
+		e.Current = cl.hit(pt)
+	} else {
+		e.Current = LayerHit{id: layer.id, layer: layer}
+	}
+	handler(e)
+	return true
+}
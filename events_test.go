@@ -0,0 +1,166 @@
+package lipgloss
+
+import (
+	"image"
+	"reflect"
+	"strings"
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+)
+
+func newEventTestCompositor() *Compositor {
+	window := NewLayer(strings.Join([]string{
+		"......",
+		"......",
+		"......",
+	}, "\n")).ID("window").X(2).Y(1).AddLayers(
+		NewLayer("ok").ID("button").X(2).Y(1),
+	)
+	return NewCompositor(NewLayer("").ID("root"), window)
+}
+
+func TestCompositorHitAll(t *testing.T) {
+	comp := newEventTestCompositor()
+
+	hits := comp.HitAll(5, 2)
+	var ids []string
+	for _, hit := range hits {
+		ids = append(ids, hit.ID())
+	}
+	if expected := []string{"button", "window"}; !reflect.DeepEqual(ids, expected) {
+		t.Fatalf("expected %v, got %v", expected, ids)
+	}
+	if local := hits[0].Local(); local != image.Pt(1, 0) {
+		t.Errorf("expected local point (1,0), got %v", local)
+	}
+	if local := hits[1].Local(); local != image.Pt(3, 1) {
+		t.Errorf("expected local point (3,1), got %v", local)
+	}
+
+	if hits := comp.HitAll(0, 0); len(hits) != 0 {
+		t.Errorf("expected no hits, got %d", len(hits))
+	}
+}
+
+func TestCompositorClickBubbling(t *testing.T) {
+	comp := newEventTestCompositor()
+
+	var calls []string
+	record := func(e *LayerEvent) {
+		calls = append(calls, e.Current.ID()+"<"+e.Target.ID())
+	}
+	comp.On("button", LayerClick, record)
+	comp.On("window", LayerClick, record)
+
+	if !comp.HandleMouse(uv.MouseClickEvent{X: 4, Y: 2, Button: uv.MouseLeft}) {
+		t.Error("expected the click to be handled")
+	}
+	if expected := []string{"button<button", "window<button"}; !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected %v, got %v", expected, calls)
+	}
+
+	// Stop propagation at the button.
+	calls = nil
+	comp.On("button", LayerClick, func(e *LayerEvent) {
+		record(e)
+		e.StopPropagation()
+	})
+	comp.HandleMouse(uv.MouseClickEvent{X: 4, Y: 2, Button: uv.MouseLeft})
+	if expected := []string{"button<button"}; !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected %v, got %v", expected, calls)
+	}
+
+	// Clicking outside of the button only reaches the window.
+	calls = nil
+	comp.HandleMouse(uv.MouseClickEvent{X: 2, Y: 1, Button: uv.MouseLeft})
+	if expected := []string{"window<window"}; !reflect.DeepEqual(calls, expected) {
+		t.Errorf("expected %v, got %v", expected, calls)
+	}
+
+	// Nothing under the mouse.
+	if comp.HandleMouse(uv.MouseClickEvent{X: 20, Y: 20}) {
+		t.Error("expected the click not to be handled")
+	}
+
+	comp.Off("window", LayerClick).Off("button", LayerClick)
+	if comp.HandleMouse(uv.MouseClickEvent{X: 4, Y: 2}) {
+		t.Error("expected the click not to be handled")
+	}
+}
+
+func TestCompositorScroll(t *testing.T) {
+	comp := newEventTestCompositor()
+
+	var button uv.MouseButton
+	comp.On("window", LayerScroll, func(e *LayerEvent) {
+		button = e.Mouse.Button
+	})
+	comp.HandleMouse(uv.MouseWheelEvent{X: 4, Y: 2, Button: uv.MouseWheelDown})
+	if button != uv.MouseWheelDown {
+		t.Errorf("expected %v, got %v", uv.MouseWheelDown, button)
+	}
+}
+
+func TestCompositorHover(t *testing.T) {
+	comp := newEventTestCompositor()
+
+	var calls []string
+	for _, id := range []string{"window", "button"} {
+		comp.On(id, LayerMouseEnter, func(e *LayerEvent) {
+			calls = append(calls, "enter:"+e.Current.ID())
+		})
+		comp.On(id, LayerMouseLeave, func(e *LayerEvent) {
+			calls = append(calls, "leave:"+e.Current.ID())
+		})
+	}
+
+	steps := []struct {
+		x, y     int
+		expected []string
+	}{
+		{4, 2, []string{"enter:window", "enter:button"}},
+		{5, 2, nil},
+		{3, 2, []string{"leave:button"}},
+		{4, 2, []string{"enter:button"}},
+		{0, 0, []string{"leave:button", "leave:window"}},
+	}
+	for i, step := range steps {
+		calls = nil
+		comp.HandleMouse(uv.MouseMotionEvent{X: step.x, Y: step.y})
+		if !reflect.DeepEqual(calls, step.expected) {
+			t.Errorf("step %d: expected %v, got %v", i, step.expected, calls)
+		}
+	}
+}
+
+func TestCompositorHoverTarget(t *testing.T) {
+	comp := newEventTestCompositor()
+
+	var calls []string
+	for _, id := range []string{"window", "button"} {
+		comp.On(id, LayerMouseEnter, func(e *LayerEvent) {
+			calls = append(calls, "enter:"+e.Current.ID()+">"+e.Target.ID())
+		})
+		comp.On(id, LayerMouseLeave, func(e *LayerEvent) {
+			calls = append(calls, "leave:"+e.Current.ID()+">"+e.Target.ID())
+		})
+	}
+
+	// Leave events target the layer the mouse left.
+	steps := []struct {
+		x, y     int
+		expected []string
+	}{
+		{4, 2, []string{"enter:window>button", "enter:button>button"}},
+		{3, 2, []string{"leave:button>button"}},
+		{0, 0, []string{"leave:window>window"}},
+	}
+	for i, step := range steps {
+		calls = nil
+		comp.HandleMouse(uv.MouseMotionEvent{X: step.x, Y: step.y})
+		if !reflect.DeepEqual(calls, step.expected) {
+			t.Errorf("step %d: expected %v, got %v", i, step.expected, calls)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package lipgloss
+
+import (
+	"image"
+	"slices"
+	"strconv"
+	"strings"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// FrameRenderer renders frames incrementally. It keeps the cells of the
+// previous frame around and, given a new frame, only emits the cursor
+// movements and writes needed to update the cells that changed.
+//
+// It's meant for standalone animations and full-screen programs that don't
+// use a framework like Bubble Tea. Frames are drawn at the top-left corner of
+// the terminal, so it's usually a good idea to use the alternate screen.
+//
+// [Graphics] are emitted after the cells of a frame, when they're new or
+// their cells were rewritten.
+//
+//	r := lipgloss.NewFrameRenderer(80, 24).SynchronizedOutput(true)
+//	for {
+//		fmt.Print(r.Render(compositor))
+//	}
+type FrameRenderer struct {
+	next *Canvas
+	prev *uv.Buffer
+
+	// The graphics placed on the previous frame.
+	graphics []graphicsPlacement
+
+	// Whether the screen needs to be cleared before the next frame.
+	clear bool
+
+	// Whether to wrap frames in synchronized output mode.
+	sync bool
+
+	// The cursor position and pen at the end of the last write.
+	cursor      uv.Position
+	cursorValid bool
+	pen         uv.Style
+	link        uv.Link
+}
+
+// NewFrameRenderer creates a new [FrameRenderer] with the given size. The
+// first frame clears the screen.
+func NewFrameRenderer(width, height int) *FrameRenderer {
+	r := &FrameRenderer{
+		next: NewCanvas(width, height),
+	}
+	r.Reset()
+	return r
+}
+
+// SynchronizedOutput sets whether frames are wrapped in synchronized output
+// mode (DEC mode 2026). Terminals that support it will draw each frame at
+// once, which prevents tearing. Terminals that don't support it ignore it.
+func (r *FrameRenderer) SynchronizedOutput(sync bool) *FrameRenderer {
+	r.sync = sync
+	return r
+}
+
+// Resize resizes the frame. The next frame clears the screen and is drawn in
+// full.
+func (r *FrameRenderer) Resize(width, height int) {
+	r.next.Resize(width, height)
+	r.Reset()
+}
+
+// Reset discards the previous frame. The next frame clears the screen and is
+// drawn in full. Call it when the screen was modified by something else.
+func (r *FrameRenderer) Reset() {
+	r.prev = uv.NewBuffer(r.next.Width(), r.next.Height())
+	r.graphics = nil
+	r.clear = true
+	r.cursorValid = false
+}
+
+// Width returns the width of the frame.
+func (r *FrameRenderer) Width() int {
+	return r.next.Width()
+}
+
+// Height returns the height of the frame.
+func (r *FrameRenderer) Height() int {
+	return r.next.Height()
+}
+
+// Render draws a [Layer], [Compositor] or any [uv.Drawable] onto a new frame
+// and returns the sequence that updates the screen from the previous frame.
+// If nothing changed, it returns an empty string.
+func (r *FrameRenderer) Render(d uv.Drawable) string {
+	r.next.Clear()
+	r.next.Compose(d)
+	next := r.next.scr.Buffer
+
+	var b strings.Builder
+	if r.clear {
+		b.WriteString(ansi.ResetStyle + ansi.EraseEntireScreen)
+		r.clear = false
+		r.cursorValid = false
+		r.pen = uv.Style{}
+		r.link = uv.Link{}
+	}
+
+	// Graphics that are gone are deleted, and their cells are rewritten.
+	// Kitty images are deleted by ID, or all at once if they don't have one,
+	// so the remaining ones must be emitted again.
+	var stale []image.Rectangle
+	var deleteAll bool
+	deleted := map[int]bool{}
+	for _, p := range r.graphics {
+		if slices.Contains(r.next.graphics, p) {
+			continue
+		}
+		stale = append(stale, p.rect)
+		switch id := p.graphics.id; {
+		case p.graphics.protocol != KittyGraphics, deleteAll, deleted[id]:
+		case id > 0:
+			b.WriteString(ansi.KittyGraphics(nil, "a=d", "d=i", "i="+strconv.Itoa(id), "q=2"))
+			deleted[id] = true
+		default:
+			b.WriteString(ansi.KittyGraphics(nil, "a=d", "d=a", "q=2"))
+			deleteAll = true
+		}
+	}
+
+	var written []image.Rectangle
+	for y := range next.Height() {
+		for x := 0; x < next.Width(); {
+			cell := next.CellAt(x, y)
+			if cell.IsZero() {
+				// Wide cell placeholder.
+				x++
+				continue
+			}
+			width := max(cell.Width, 1)
+			if !cell.Equal(r.prev.CellAt(x, y)) || overlapsAny(stale, image.Rect(x, y, x+width, y+1)) {
+				r.moveTo(&b, x, y)
+				r.writeCell(&b, cell)
+				written = append(written, image.Rect(x, y, x+width, y+1))
+			}
+			x += width
+		}
+	}
+
+	if r.link != (uv.Link{}) {
+		b.WriteString(ansi.ResetHyperlink())
+		r.link = uv.Link{}
+	}
+	if !r.pen.IsZero() {
+		b.WriteString(ansi.ResetStyle)
+		r.pen = uv.Style{}
+	}
+
+	// Graphics are emitted over their blank cells, unless they're already on
+	// the screen.
+	for _, p := range r.next.graphics {
+		kept := slices.Contains(r.graphics, p) && !overlapsAny(written, p.rect)
+		if p.graphics.protocol == KittyGraphics && (deleteAll || deleted[p.graphics.id]) {
+			kept = false
+		}
+		if kept {
+			continue
+		}
+		r.moveTo(&b, p.rect.Min.X, p.rect.Min.Y)
+		b.WriteString(p.seq)
+		if p.graphics.protocol != KittyGraphics {
+			// Only Kitty images are told not to move the cursor.
+			r.cursorValid = false
+		}
+	}
+
+	r.prev = next.Clone()
+	r.graphics = slices.Clone(r.next.graphics)
+
+	if b.Len() == 0 {
+		return ""
+	}
+	if r.sync {
+		return ansi.SetModeSynchronizedOutput + b.String() + ansi.ResetModeSynchronizedOutput
+	}
+	return b.String()
+}
+
+// moveTo moves the cursor to the given position using the shortest available
+// sequence.
+func (r *FrameRenderer) moveTo(b *strings.Builder, x, y int) {
+	if r.cursorValid && r.cursor == uv.Pos(x, y) {
+		return
+	}
+
+	seq := ansi.CursorPosition(x+1, y+1)
+	if r.cursorValid {
+		var candidates []string
+		switch {
+		case y == r.cursor.Y && x > r.cursor.X:
+			candidates = append(candidates,
+				ansi.CursorForward(x-r.cursor.X),
+				ansi.CursorHorizontalAbsolute(x+1))
+		case y == r.cursor.Y && x < r.cursor.X:
+			candidates = append(candidates,
+				ansi.CursorBackward(r.cursor.X-x),
+				ansi.CursorHorizontalAbsolute(x+1))
+		case y > r.cursor.Y && x == 0:
+			candidates = append(candidates, ansi.CursorNextLine(y-r.cursor.Y))
+		}
+		for _, c := range candidates {
+			if len(c) < len(seq) {
+				seq = c
+			}
+		}
+	}
+
+	b.WriteString(seq)
+	r.cursor = uv.Pos(x, y)
+	r.cursorValid = true
+}
+
+// writeCell writes a cell at the current cursor position, updating the pen
+// and hyperlink as needed.
+func (r *FrameRenderer) writeCell(b *strings.Builder, cell *uv.Cell) {
+	if cell.Style.IsZero() && !r.pen.IsZero() {
+		b.WriteString(ansi.ResetStyle)
+		r.pen = uv.Style{}
+	}
+	if !cell.Style.Equal(&r.pen) {
+		b.WriteString(cell.Style.Diff(&r.pen))
+		r.pen = cell.Style
+	}
+
+	if cell.Link != r.link {
+		if r.link.URL != "" {
+			b.WriteString(ansi.ResetHyperlink())
+		}
+		if cell.Link.URL != "" {
+			b.WriteString(ansi.SetHyperlink(cell.Link.URL, cell.Link.Params))
+		}
+		r.link = cell.Link
+	}
+
+	b.WriteString(cell.String())
+
+	r.cursor.X += max(cell.Width, 1)
+	if r.cursor.X >= r.next.Width() {
+		// The cursor is in the pending wrap state at the right margin, so
+		// its position is not reliable anymore.
+		r.cursorValid = false
+	}
+}
+
+// overlapsAny returns whether the rectangle overlaps any of the rectangles.
+func overlapsAny(rects []image.Rectangle, rect image.Rectangle) bool {
+	return slices.ContainsFunc(rects, rect.Overlaps)
+}
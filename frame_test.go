@@ -0,0 +1,88 @@
+package lipgloss
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestFrameRenderer(t *testing.T) {
+	r := NewFrameRenderer(4, 2)
+
+	// The first frame clears the screen and skips blank cells.
+	got := r.Render(NewLayer("ab\ncd"))
+	expected := ansi.ResetStyle + ansi.EraseEntireScreen +
+		ansi.CursorHomePosition + "ab" +
+		ansi.CursorNextLine(1) + "cd"
+	if got != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, got)
+	}
+
+	// Nothing changed.
+	if got := r.Render(NewLayer("ab\ncd")); got != "" {
+		t.Errorf("expected empty frame, got %q", got)
+	}
+
+	// Only the changed cells are written. The cursor is still at the end of
+	// the previous frame.
+	got = r.Render(NewLayer("ab\nxd"))
+	expected = ansi.CursorBackward(2) + "x"
+	if got != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, got)
+	}
+
+	got = r.Render(NewLayer("ab y\nxd"))
+	expected = ansi.CursorPosition(4, 1) + "y"
+	if got != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, got)
+	}
+
+	// Removed cells are replaced with blanks.
+	got = r.Render(NewLayer("ab y"))
+	expected = ansi.CursorPosition(1, 2) + "  "
+	if got != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, got)
+	}
+}
+
+func TestFrameRendererStyles(t *testing.T) {
+	r := NewFrameRenderer(3, 1)
+	r.Render(NewLayer("abc"))
+
+	got := r.Render(NewLayer("a" + NewStyle().Bold(true).Render("B") + "c"))
+	expected := ansi.CursorPosition(2, 1) + "\x1b[1mB" + ansi.ResetStyle
+	if got != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, got)
+	}
+}
+
+func TestFrameRendererSynchronizedOutput(t *testing.T) {
+	r := NewFrameRenderer(2, 1).SynchronizedOutput(true)
+	r.Render(NewLayer("ab"))
+
+	got := r.Render(NewLayer("xb"))
+	expected := ansi.SetModeSynchronizedOutput +
+		ansi.CursorHomePosition + "x" +
+		ansi.ResetModeSynchronizedOutput
+	if got != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, got)
+	}
+
+	// Empty frames are not wrapped.
+	if got := r.Render(NewLayer("xb")); got != "" {
+		t.Errorf("expected empty frame, got %q", got)
+	}
+}
+
+func TestFrameRendererReset(t *testing.T) {
+	r := NewFrameRenderer(2, 1)
+	r.Render(NewLayer("ab"))
+
+	r.Resize(3, 1)
+	got := r.Render(NewLayer("abc"))
+	expected := ansi.ResetStyle + ansi.EraseEntireScreen +
+		ansi.CursorHomePosition + "abc"
+	if got != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, got)
+	}
+}
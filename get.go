@@ -114,6 +114,12 @@ func (s Style) GetAlignVertical() Position {
 	return v
 }
 
+// GetAlignDecimal returns the style's decimal separator numbers are lined up
+// on. If no value is set 0 is returned.
+func (s Style) GetAlignDecimal() rune {
+	return s.getAsRune(alignDecimalKey)
+}
+
 // GetPadding returns the style's top, right, bottom, and left padding values,
 // in that order. 0 is returned for unset values.
 func (s Style) GetPadding() (top, right, bottom, left int) {
@@ -493,6 +499,8 @@ func (s Style) getAsRune(k propKey) rune {
 		return 0
 	}
 	switch k { //nolint:exhaustive
+	case alignDecimalKey:
+		return s.alignDecimal
 	case paddingCharKey:
 		return s.paddingChar
 	case marginCharKey:
@@ -0,0 +1,389 @@
+package lipgloss
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"strconv"
+	"strings"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/ansi/kitty"
+)
+
+// GraphicsProtocol is the protocol used to draw [Graphics].
+type GraphicsProtocol int
+
+// Graphics protocols.
+const (
+	// HalfBlockGraphics draws images with half blocks, like [Image] does. It
+	// works in any terminal with color support.
+	HalfBlockGraphics GraphicsProtocol = iota
+
+	// KittyGraphics draws images with the Kitty graphics protocol, which is
+	// supported by Kitty, WezTerm and Ghostty, among others.
+	KittyGraphics
+
+	// SixelGraphics draws images with Sixel, which is supported by foot,
+	// mlterm and xterm (when compiled with Sixel support), among others.
+	SixelGraphics
+)
+
+// String returns the name of the protocol.
+func (p GraphicsProtocol) String() string {
+	switch p {
+	case KittyGraphics:
+		return "kitty"
+	case SixelGraphics:
+		return "sixel"
+	default:
+		return "halfblock"
+	}
+}
+
+// DetectGraphics guesses the graphics protocol supported by the terminal from
+// the given environment variables, as returned by [os.Environ]. If no
+// protocol is detected, or the program runs inside of a terminal
+// multiplexer, it returns [HalfBlockGraphics].
+//
+//	protocol := lipgloss.DetectGraphics(os.Environ())
+func DetectGraphics(environ []string) GraphicsProtocol {
+	env := make(map[string]string, len(environ))
+	for _, kv := range environ {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+
+	term := env["TERM"]
+	switch {
+	case env["TMUX"] != "" || strings.HasPrefix(term, "screen"):
+		// Multiplexers need the sequences to be wrapped in passthrough
+		// sequences, if they support them at all.
+		return HalfBlockGraphics
+	case env["KITTY_WINDOW_ID"] != "",
+		term == "xterm-kitty",
+		term == "xterm-ghostty",
+		env["TERM_PROGRAM"] == "WezTerm",
+		env["TERM_PROGRAM"] == "ghostty":
+		return KittyGraphics
+	case strings.HasPrefix(term, "foot"),
+		strings.HasPrefix(term, "mlterm"),
+		strings.HasPrefix(term, "contour"):
+		return SixelGraphics
+	default:
+		return HalfBlockGraphics
+	}
+}
+
+// Default size of a terminal cell in pixels, used to scale Sixel images.
+const (
+	defaultCellPixelWidth  = 10
+	defaultCellPixelHeight = 20
+)
+
+// Graphics draws an [image.Image] using a terminal graphics protocol, which
+// displays the image at the full resolution of the terminal instead of
+// approximating it with cells. With [HalfBlockGraphics], it's drawn like an
+// [Image].
+//
+// It implements [uv.Drawable], so it can be composed onto a [Canvas] or used
+// as the content of a [Layer] with [NewDrawableLayer]:
+//
+//	preview := lipgloss.NewGraphics(img, lipgloss.DetectGraphics(os.Environ()))
+//	layer := lipgloss.NewDrawableLayer(preview.Size(40, 0))
+//
+// The image is only drawn with the graphics protocol onto a [Canvas], which
+// leaves its cells blank and emits the image after them, see [Canvas.Render]
+// and [FrameRenderer]. It falls back to half blocks when it's drawn onto
+// another screen, when it doesn't fit in the area it's drawn in, for instance
+// because it's clipped by a parent [Layer], and when its cells are drawn
+// over, for instance by a layer on top of it.
+type Graphics struct {
+	protocol GraphicsProtocol
+	image    *Image
+
+	id                    int
+	cellWidth, cellHeight int
+
+	// Cached sequence, computed on the first draw.
+	seq string
+}
+
+var _ uv.Drawable = (*Graphics)(nil)
+
+// NewGraphics creates new [Graphics] from the given image, drawn with the
+// given protocol. By default, each pixel of the image is mapped to half a
+// cell, like [NewImage] does.
+func NewGraphics(img image.Image, protocol GraphicsProtocol) *Graphics {
+	return &Graphics{
+		protocol:   protocol,
+		image:      NewImage(img),
+		cellWidth:  defaultCellPixelWidth,
+		cellHeight: defaultCellPixelHeight,
+	}
+}
+
+// Size sets the size of the Graphics in cells. See [Image.Size].
+func (g *Graphics) Size(width, height int) *Graphics {
+	g.image.Size(width, height)
+	g.seq = ""
+	return g
+}
+
+// ID sets the image ID used by the Kitty graphics protocol. Images with the
+// same ID replace each other, which is useful to update a preview in place.
+// By default, the terminal assigns IDs itself.
+func (g *Graphics) ID(id int) *Graphics {
+	g.id = max(id, 0)
+	g.seq = ""
+	return g
+}
+
+// CellSize sets the size of a terminal cell in pixels, which is used to scale
+// the image to its size in cells with Sixel. The Kitty graphics protocol
+// scales images in the terminal, so it doesn't need it. The default is 10x20.
+func (g *Graphics) CellSize(width, height int) *Graphics {
+	g.cellWidth, g.cellHeight = max(width, 1), max(height, 1)
+	g.seq = ""
+	return g
+}
+
+// Fallback returns the [Image] used to draw the Graphics with half blocks.
+// It can be used to set the color profile and dithering of the fallback.
+func (g *Graphics) Fallback() *Image {
+	return g.image
+}
+
+// Bounds returns the bounds of the Graphics in cells.
+func (g *Graphics) Bounds() uv.Rectangle {
+	return g.image.Bounds()
+}
+
+// Draw draws the Graphics onto the screen at the given area.
+//
+// It implements [uv.Drawable].
+func (g *Graphics) Draw(scr uv.Screen, area uv.Rectangle) {
+	width, height := g.image.size()
+	rect := image.Rectangle{Min: area.Min, Max: area.Min.Add(image.Pt(width, height))}
+	canvas, visible := canvasArea(scr, area)
+	if g.protocol == HalfBlockGraphics || width == 0 || height == 0 ||
+		canvas == nil || !rect.In(visible) {
+		g.image.Draw(scr, area)
+		return
+	}
+
+	if g.seq == "" {
+		var b strings.Builder
+		if err := g.Encode(&b); err != nil {
+			g.image.Draw(scr, area)
+			return
+		}
+		g.seq = b.String()
+	}
+
+	// The cells of the image are left blank, and the canvas emits the image
+	// over them after it renders its cells.
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			scr.SetCell(x, y, &uv.EmptyCell)
+		}
+	}
+	canvas.placeGraphics(g, rect, g.seq)
+}
+
+// graphicsPlacement is the placement of [Graphics] on a [Canvas].
+type graphicsPlacement struct {
+	graphics *Graphics
+	rect     image.Rectangle
+	seq      string
+}
+
+// canvasArea returns the [Canvas] under the screen, if any, and the part of
+// the area the screen doesn't clip.
+func canvasArea(scr uv.Screen, area image.Rectangle) (*Canvas, image.Rectangle) {
+	area = area.Intersect(scr.Bounds())
+	for {
+		switch s := scr.(type) {
+		case clipScreen:
+			area = area.Intersect(s.clip)
+			scr = s.Screen
+		case *Canvas:
+			return s, area
+		default:
+			return nil, area
+		}
+	}
+}
+
+// Encode writes the escape sequence that draws the image at the cursor
+// position to w. With [HalfBlockGraphics], it writes the rendered half
+// blocks. Nothing is written for an empty image.
+func (g *Graphics) Encode(w io.Writer) error {
+	width, height := g.image.size()
+	if width == 0 || height == 0 {
+		return nil
+	}
+	switch g.protocol {
+	case KittyGraphics:
+		return kitty.EncodeGraphics(w, g.image.img, &kitty.Options{ //nolint:wrapcheck
+			Action:          kitty.TransmitAndPut,
+			Transmission:    kitty.Direct,
+			Format:          kitty.PNG,
+			Quite:           2, //nolint:mnd
+			ID:              g.id,
+			Columns:         width,
+			Rows:            height,
+			DoNotMoveCursor: true,
+			Chunk:           true,
+		})
+	case SixelGraphics:
+		img := scaleImage(g.image.img, width*g.cellWidth, height*g.cellHeight)
+		var payload bytes.Buffer
+		encodeSixel(&payload, img)
+		_, err := io.WriteString(w, ansi.SixelGraphics(0, 1, 0, payload.Bytes()))
+		return err //nolint:wrapcheck
+	default:
+		c := NewCanvas(width, height)
+		c.Compose(g.image)
+		_, err := io.WriteString(w, c.Render())
+		return err //nolint:wrapcheck
+	}
+}
+
+// scaleImage scales an image to the given size in pixels, averaging the
+// pixels that end up in the same place.
+func scaleImage(img image.Image, width, height int) *image.NRGBA {
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	src := img.Bounds()
+	if src.Empty() {
+		return dst
+	}
+	for y := range height {
+		y0 := src.Min.Y + y*src.Dy()/height
+		y1 := max(src.Min.Y+(y+1)*src.Dy()/height, y0+1)
+		for x := range width {
+			x0 := src.Min.X + x*src.Dx()/width
+			x1 := max(src.Min.X+(x+1)*src.Dx()/width, x0+1)
+			if p := averagePixel(img, image.Rect(x0, y0, x1, y1)); p.opaque {
+				dst.SetNRGBA(x, y, color.NRGBA{R: channel(p.r), G: channel(p.g), B: channel(p.b), A: 0xff})
+			}
+		}
+	}
+	return dst
+}
+
+// Sixel encoding.
+const (
+	sixelMaxColors  = 256
+	sixelBandHeight = 6
+	sixelOffset     = '?'
+)
+
+// encodeSixel writes the Sixel data of an image, without the surrounding
+// DCS sequence. Transparent pixels are left untouched. Images with more than
+// 256 colors are reduced to a 6x6x6 color cube.
+func encodeSixel(w *bytes.Buffer, img *image.NRGBA) {
+	b := img.Bounds()
+	fmt.Fprintf(w, "\"1;1;%d;%d", b.Dx(), b.Dy())
+
+	// Build the palette in order of appearance, so the output is stable.
+	indices := make([]int, b.Dx()*b.Dy())
+	var palette []color.NRGBA
+	lookup := map[color.NRGBA]int{}
+	collect := func(quantize bool) bool {
+		palette, lookup = palette[:0], map[color.NRGBA]int{}
+		for y := range b.Dy() {
+			for x := range b.Dx() {
+				c := img.NRGBAAt(b.Min.X+x, b.Min.Y+y)
+				if c.A == 0 {
+					indices[y*b.Dx()+x] = -1
+					continue
+				}
+				if quantize {
+					c = quantizeCube(c)
+				}
+				i, ok := lookup[c]
+				if !ok {
+					if len(palette) == sixelMaxColors {
+						return false
+					}
+					i = len(palette)
+					lookup[c] = i
+					palette = append(palette, c)
+				}
+				indices[y*b.Dx()+x] = i
+			}
+		}
+		return true
+	}
+	if !collect(false) {
+		collect(true)
+	}
+
+	for i, c := range palette {
+		// Sixel colors are in percent.
+		fmt.Fprintf(w, "#%d;2;%d;%d;%d", i,
+			int(c.R)*100/0xff, int(c.G)*100/0xff, int(c.B)*100/0xff) //nolint:mnd
+	}
+
+	for band := 0; band < b.Dy(); band += sixelBandHeight {
+		if band > 0 {
+			w.WriteByte('-')
+		}
+		first := true
+		for i := range palette {
+			row := make([]byte, b.Dx())
+			used := false
+			for x := range b.Dx() {
+				var bits byte
+				for dy := range min(sixelBandHeight, b.Dy()-band) {
+					if indices[(band+dy)*b.Dx()+x] == i {
+						bits |= 1 << dy
+					}
+				}
+				row[x] = sixelOffset + bits
+				used = used || bits != 0
+			}
+			if !used {
+				continue
+			}
+			if !first {
+				w.WriteByte('$')
+			}
+			first = false
+			w.WriteString("#" + strconv.Itoa(i))
+			writeSixelRow(w, bytes.TrimRight(row, "?"))
+		}
+	}
+}
+
+// writeSixelRow writes a row of sixels, compressing runs of the same sixel.
+func writeSixelRow(w *bytes.Buffer, row []byte) {
+	for i := 0; i < len(row); {
+		j := i + 1
+		for j < len(row) && row[j] == row[i] {
+			j++
+		}
+		if n := j - i; n > 3 { //nolint:mnd
+			w.WriteString("!" + strconv.Itoa(n))
+			w.WriteByte(row[i])
+		} else {
+			for range n {
+				w.WriteByte(row[i])
+			}
+		}
+		i = j
+	}
+}
+
+// quantizeCube maps a color to the closest color of a 6x6x6 color cube.
+func quantizeCube(c color.NRGBA) color.NRGBA {
+	q := func(v uint8) uint8 {
+		return uint8((int(v)*5 + 0x7f) / 0xff * 0x33) //nolint:mnd
+	}
+	return color.NRGBA{R: q(c.R), G: q(c.G), B: q(c.B), A: 0xff}
+}
@@ -0,0 +1,217 @@
+package lipgloss
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/exp/golden"
+)
+
+// graphicsTestImage returns a 4x4 image with a red, green, blue and
+// transparent quadrant.
+func graphicsTestImage() image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for y := range 4 {
+		for x := range 4 {
+			switch {
+			case x < 2 && y < 2:
+				img.Set(x, y, color.NRGBA{R: 0xff, A: 0xff})
+			case y < 2:
+				img.Set(x, y, color.NRGBA{G: 0xff, A: 0xff})
+			case x < 2:
+				img.Set(x, y, color.NRGBA{B: 0xff, A: 0xff})
+			}
+		}
+	}
+	return img
+}
+
+func TestGraphicsEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		graphics *Graphics
+	}{
+		{"Kitty", NewGraphics(graphicsTestImage(), KittyGraphics).ID(7)},
+		{"Sixel", NewGraphics(graphicsTestImage(), SixelGraphics).CellSize(2, 3)},
+		{"HalfBlock", NewGraphics(graphicsTestImage(), HalfBlockGraphics)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := tc.graphics.Encode(&b); err != nil {
+				t.Fatal(err)
+			}
+			golden.RequireEqual(t, []byte(b.String()))
+		})
+	}
+}
+
+func TestGraphicsSixelColorCube(t *testing.T) {
+	// More colors than fit in a Sixel palette.
+	img := image.NewNRGBA(image.Rect(0, 0, 32, 12))
+	for y := range 12 {
+		for x := range 32 {
+			img.Set(x, y, color.NRGBA{R: uint8(x * 8), G: uint8(y * 20), B: 0x80, A: 0xff})
+		}
+	}
+
+	var b strings.Builder
+	if err := NewGraphics(img, SixelGraphics).CellSize(1, 2).Encode(&b); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(b.String(), ";2;"); n == 0 || n > 256 {
+		t.Errorf("expected at most 256 palette colors, got %d", n)
+	}
+}
+
+func TestGraphicsDraw(t *testing.T) {
+	g := NewGraphics(graphicsTestImage(), KittyGraphics)
+	var seq strings.Builder
+	if err := g.Encode(&seq); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewCanvas(6, 3)
+	c.Compose(NewLayer("abcdef"))
+	g.Draw(c, image.Rect(1, 1, 6, 3))
+
+	// The cells of the image are blank, so they measure and snapshot like
+	// any other cells.
+	for y := 1; y < 3; y++ {
+		for x := 1; x < 5; x++ {
+			if cell := c.CellAt(x, y); !cell.Equal(&uv.EmptyCell) {
+				t.Errorf("expected a blank cell at %d,%d, got %q", x, y, cell.Content)
+			}
+		}
+	}
+	if got := c.Snapshot().String(); strings.Contains(got, "\x1b") {
+		t.Errorf("expected no escape sequences in the snapshot, got %q", got)
+	}
+
+	// The image is emitted after the cells, from the end of the last line.
+	expected := "abcdef\n\n" + ansi.SaveCursor + "\r" + ansi.CursorUp(1) +
+		ansi.CursorForward(1) + seq.String() + ansi.RestoreCursor
+	if got := c.Render(); got != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, got)
+	}
+}
+
+func TestGraphicsOverlap(t *testing.T) {
+	// A layer on top of the image covers part of it, so it's drawn with
+	// half blocks.
+	c := NewCanvas(4, 2)
+	c.Compose(NewCompositor(
+		NewLayer("", NewDrawableLayer(NewGraphics(graphicsTestImage(), KittyGraphics))),
+		NewLayer("x").X(3).Y(1).Z(1),
+	))
+
+	if got := c.CellAt(0, 0).Content; got != "▀" {
+		t.Errorf("expected a half block, got %q", got)
+	}
+	if got := c.CellAt(3, 1).Content; got != "x" {
+		t.Errorf("expected the layer on top of the image, got %q", got)
+	}
+	if got := c.Render(); strings.Contains(got, ansi.SaveCursor) {
+		t.Errorf("expected no graphics sequence, got %q", got)
+	}
+}
+
+func TestGraphicsFrameRenderer(t *testing.T) {
+	g := NewGraphics(graphicsTestImage(), KittyGraphics)
+	var seq strings.Builder
+	if err := g.Encode(&seq); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewFrameRenderer(6, 2)
+	l := NewCompositor(NewDrawableLayer(g).X(1))
+	if got := r.Render(l); !strings.HasSuffix(got, ansi.CursorPosition(2, 1)+seq.String()) {
+		t.Errorf("expected the image after the frame, got %q", got)
+	}
+
+	// The image is already on the screen.
+	if got := r.Render(l); got != "" {
+		t.Errorf("expected empty frame, got %q", got)
+	}
+
+	// The image is gone, so it's deleted.
+	got := r.Render(NewLayer("ab"))
+	if !strings.Contains(got, ansi.KittyGraphics(nil, "a=d", "d=a", "q=2")) {
+		t.Errorf("expected the image to be deleted, got %q", got)
+	}
+}
+
+func TestGraphicsFrameRendererID(t *testing.T) {
+	g := NewGraphics(graphicsTestImage(), KittyGraphics).ID(7)
+	other := NewGraphics(graphicsTestImage(), KittyGraphics)
+	r := NewFrameRenderer(8, 2)
+	r.Render(NewCompositor(NewDrawableLayer(g), NewDrawableLayer(other).X(4)))
+
+	// Only the image with the ID is gone, so the other one is left alone.
+	got := r.Render(NewCompositor(NewDrawableLayer(other).X(4)))
+	if !strings.Contains(got, ansi.KittyGraphics(nil, "a=d", "d=i", "i=7", "q=2")) {
+		t.Errorf("expected the image to be deleted by ID, got %q", got)
+	}
+	if strings.Contains(got, "d=a") {
+		t.Errorf("expected the other image to be kept, got %q", got)
+	}
+}
+
+func TestGraphicsFallback(t *testing.T) {
+	// The image is clipped by its parent, so it's drawn with half blocks.
+	l := NewLayer("",
+		NewDrawableLayer(NewGraphics(graphicsTestImage(), KittyGraphics)),
+	).Clip(image.Rect(0, 0, 3, 2))
+
+	c := NewCanvas(4, 2)
+	c.Compose(NewCompositor(l))
+
+	cell := c.CellAt(0, 0)
+	if cell.Content != "▀" {
+		t.Errorf("expected a half block, got %q", cell.Content)
+	}
+	if got := toRGBA(cell.Style.Fg); got != (color.RGBA{R: 0xff, A: 0xff}) {
+		t.Errorf("expected red, got %v", got)
+	}
+	if got := c.CellAt(3, 0).Content; got != " " {
+		t.Errorf("expected the image to be clipped, got %q", got)
+	}
+}
+
+func TestGraphicsNil(t *testing.T) {
+	for _, protocol := range []GraphicsProtocol{HalfBlockGraphics, KittyGraphics, SixelGraphics} {
+		g := NewGraphics(nil, protocol)
+		var b strings.Builder
+		if err := g.Encode(&b); err != nil || b.Len() > 0 {
+			t.Errorf("%s: expected nothing to be encoded, got %q (%v)", protocol, b.String(), err)
+		}
+
+		c := NewCanvas(4, 2).Compose(g)
+		if got := c.Render(); got != "\n" {
+			t.Errorf("%s: expected a blank canvas, got %q", protocol, got)
+		}
+	}
+}
+
+func TestDetectGraphics(t *testing.T) {
+	tests := []struct {
+		env      []string
+		expected GraphicsProtocol
+	}{
+		{[]string{"TERM=xterm-kitty"}, KittyGraphics},
+		{[]string{"TERM=xterm-256color", "TERM_PROGRAM=WezTerm"}, KittyGraphics},
+		{[]string{"TERM=foot"}, SixelGraphics},
+		{[]string{"TERM=xterm-256color"}, HalfBlockGraphics},
+		{[]string{"TERM=xterm-kitty", "TMUX=/tmp/tmux-1000/default,1,0"}, HalfBlockGraphics},
+		{nil, HalfBlockGraphics},
+	}
+	for _, tc := range tests {
+		if got := DetectGraphics(tc.env); got != tc.expected {
+			t.Errorf("%v: expected %s, got %s", tc.env, tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package lipgloss
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/charmbracelet/colorprofile"
+	uv "github.com/charmbracelet/ultraviolet"
+)
+
+// Image draws an [image.Image] onto terminal cells. Each cell holds two
+// pixels stacked on top of each other, drawn with the upper half block (▀)
+// using the top pixel as the foreground color and the bottom one as the
+// background color. Since terminal cells are roughly twice as tall as they
+// are wide, this keeps pixels about square.
+//
+// It implements [uv.Drawable], so it can be composed onto a [Canvas] or used
+// as the content of a [Layer] with [NewDrawableLayer]:
+//
+//	logo := lipgloss.NewImage(img).Size(20, 0)
+//	layer := lipgloss.NewDrawableLayer(logo).X(2).Y(1)
+//
+// Transparent pixels are not drawn, so whatever is underneath shows through.
+type Image struct {
+	img           image.Image
+	width, height int
+	profile       colorprofile.Profile
+	dither        bool
+
+	// Cached cells, computed on the first draw.
+	cells []*uv.Cell
+}
+
+var _ uv.Drawable = (*Image)(nil)
+
+// NewImage creates a new [Image] from the given image. By default, each
+// pixel of the image is mapped to half a cell.
+func NewImage(img image.Image) *Image {
+	return &Image{img: img, profile: colorprofile.TrueColor}
+}
+
+// Size sets the size of the Image in cells. The image is scaled to fit. If
+// either the width or height is 0, it's calculated from the other one to keep
+// the aspect ratio of the image. If both are 0, each pixel is mapped to half a
+// cell.
+func (i *Image) Size(width, height int) *Image {
+	i.width, i.height = max(width, 0), max(height, 0)
+	i.cells = nil
+	return i
+}
+
+// Profile sets the color profile the Image is drawn with. For the ANSI and
+// ANSI256 profiles, colors are converted to the closest color of the
+// profile's palette. Other profiles use the colors of the image as-is.
+func (i *Image) Profile(p colorprofile.Profile) *Image {
+	i.profile = p
+	i.cells = nil
+	return i
+}
+
+// Dither sets whether to use Floyd-Steinberg dithering when converting the
+// colors of the image to the palette of an ANSI or ANSI256 [Image.Profile].
+// Dithering makes gradients and photos look a lot smoother with a limited
+// palette.
+func (i *Image) Dither(dither bool) *Image {
+	i.dither = dither
+	i.cells = nil
+	return i
+}
+
+// Bounds returns the bounds of the Image in cells.
+func (i *Image) Bounds() uv.Rectangle {
+	width, height := i.size()
+	return uv.Rect(0, 0, width, height)
+}
+
+// size returns the size of the Image in cells.
+func (i *Image) size() (width, height int) {
+	if i.img == nil {
+		return 0, 0
+	}
+	b := i.img.Bounds()
+	if b.Empty() {
+		return 0, 0
+	}
+
+	switch width, height = i.width, i.height; {
+	case width == 0 && height == 0:
+		return b.Dx(), (b.Dy() + 1) / 2 //nolint:mnd
+	case height == 0:
+		height = int(math.Round(float64(width*b.Dy()) / float64(b.Dx()) / 2)) //nolint:mnd
+	case width == 0:
+		width = int(math.Round(float64(2*height*b.Dx()) / float64(b.Dy()))) //nolint:mnd
+	}
+	return max(width, 1), max(height, 1)
+}
+
+// Draw draws the Image onto the screen at the given area. The Image is not
+// scaled to the area; use [Image.Size] for that.
+//
+// It implements [uv.Drawable].
+func (i *Image) Draw(scr uv.Screen, area uv.Rectangle) {
+	width, height := i.size()
+	if width == 0 || height == 0 {
+		return
+	}
+	if i.cells == nil {
+		i.cells = i.render(width, height)
+	}
+	for y := range height {
+		for x := range width {
+			cell := i.cells[y*width+x]
+			if cell == nil {
+				continue
+			}
+			pt := area.Min.Add(image.Pt(x, y))
+			if pt.In(area) {
+				scr.SetCell(pt.X, pt.Y, cell)
+			}
+		}
+	}
+}
+
+// render converts the image to cells of the given size. Cells where both
+// pixels are transparent are nil.
+func (i *Image) render(width, height int) []*uv.Cell {
+	pixels := i.pixels(width, height*2) //nolint:mnd
+	cells := make([]*uv.Cell, width*height)
+	for y := range height {
+		for x := range width {
+			top, bottom := pixels[2*y*width+x], pixels[(2*y+1)*width+x]
+			var cell *uv.Cell
+			switch {
+			case top == nil && bottom == nil:
+				continue
+			case bottom == nil:
+				cell = &uv.Cell{Content: "▀", Width: 1}
+				cell.Style.Fg = top
+			case top == nil:
+				cell = &uv.Cell{Content: "▄", Width: 1}
+				cell.Style.Fg = bottom
+			default:
+				cell = &uv.Cell{Content: "▀", Width: 1}
+				cell.Style.Fg = top
+				cell.Style.Bg = bottom
+			}
+			cells[y*width+x] = cell
+		}
+	}
+	return cells
+}
+
+// pixel is a color in floating point, so that dithering errors can be
+// accumulated.
+type pixel struct {
+	r, g, b float64
+	opaque  bool
+}
+
+// pixels scales the image to the given size in pixels and converts its colors
+// to the profile. Transparent pixels are nil.
+func (i *Image) pixels(width, height int) []color.Color {
+	src := i.img.Bounds()
+	rows := height
+	if i.width == 0 && i.height == 0 {
+		// Images with an odd height have a transparent bottom row of pixels
+		// at their natural size.
+		rows = src.Dy()
+	}
+
+	scaled := make([]pixel, width*height)
+	for y := range rows {
+		y0 := src.Min.Y + y*src.Dy()/rows
+		y1 := max(src.Min.Y+(y+1)*src.Dy()/rows, y0+1)
+		for x := range width {
+			x0 := src.Min.X + x*src.Dx()/width
+			x1 := max(src.Min.X+(x+1)*src.Dx()/width, x0+1)
+			scaled[y*width+x] = averagePixel(i.img, image.Rect(x0, y0, x1, y1))
+		}
+	}
+
+	quantize := i.profile == colorprofile.ANSI || i.profile == colorprofile.ANSI256
+	colors := make([]color.Color, width*height)
+	for y := range height {
+		for x := range width {
+			p := scaled[y*width+x]
+			if !p.opaque {
+				continue
+			}
+			c := color.RGBA{R: channel(p.r), G: channel(p.g), B: channel(p.b), A: 0xff}
+			if !quantize {
+				colors[y*width+x] = c
+				continue
+			}
+
+			q := i.profile.Convert(c)
+			colors[y*width+x] = q
+			if !i.dither {
+				continue
+			}
+
+			// Spread the quantization error to the neighboring pixels that
+			// haven't been visited yet.
+			qr, qg, qb, _ := q.RGBA()
+			er := float64(c.R) - float64(qr>>8)
+			eg := float64(c.G) - float64(qg>>8)
+			eb := float64(c.B) - float64(qb>>8)
+			spread := func(x, y int, weight float64) {
+				if x < 0 || x >= width || y >= height || !scaled[y*width+x].opaque {
+					return
+				}
+				n := &scaled[y*width+x]
+				n.r += er * weight
+				n.g += eg * weight
+				n.b += eb * weight
+			}
+			spread(x+1, y, 7.0/16)   //nolint:mnd
+			spread(x-1, y+1, 3.0/16) //nolint:mnd
+			spread(x, y+1, 5.0/16)   //nolint:mnd
+			spread(x+1, y+1, 1.0/16) //nolint:mnd
+		}
+	}
+	return colors
+}
+
+// averagePixel returns the average color of the given area of an image. The
+// result is opaque if at least half of the area is.
+func averagePixel(img image.Image, area image.Rectangle) pixel {
+	var r, g, b, a float64
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			pr, pg, pb, pa := img.At(x, y).RGBA()
+			r += float64(pr)
+			g += float64(pg)
+			b += float64(pb)
+			a += float64(pa)
+		}
+	}
+	n := float64(area.Dx() * area.Dy())
+	if a < n*0xffff/2 {
+		return pixel{}
+	}
+
+	// Colors are premultiplied by alpha, so dividing by the total alpha
+	// averages and un-premultiplies them at once.
+	scale := 0xff / a
+	return pixel{r: r * scale, g: g * scale, b: b * scale, opaque: true}
+}
+
+// channel converts a floating point color channel to a byte.
+func channel(v float64) uint8 {
+	return uint8(math.Round(clamp(v, 0, 0xff)))
+}
@@ -0,0 +1,152 @@
+package lipgloss
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/charmbracelet/colorprofile"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var (
+	imageRed  = color.RGBA{R: 0xff, A: 0xff}
+	imageBlue = color.RGBA{B: 0xff, A: 0xff}
+)
+
+func TestImageHalfBlocks(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 3, 3))
+	img.Set(0, 0, imageRed)
+	img.Set(0, 1, imageBlue)
+	img.Set(1, 0, imageRed)
+	img.Set(2, 1, imageBlue)
+	img.Set(2, 2, imageRed)
+
+	i := NewImage(img)
+	if got := i.Bounds(); got.Dx() != 3 || got.Dy() != 2 {
+		t.Fatalf("expected a 3x2 image, got %dx%d", got.Dx(), got.Dy())
+	}
+
+	c := NewCanvas(3, 2)
+	c.Text(0, 0, "abc", NewStyle())
+	c.Text(0, 1, "def", NewStyle())
+	c.Compose(i)
+
+	tests := []struct {
+		x, y    int
+		content string
+		fg, bg  color.Color
+	}{
+		{0, 0, "▀", imageRed, imageBlue},
+		{1, 0, "▀", imageRed, nil},
+		{2, 0, "▄", imageBlue, nil},
+		{0, 1, "d", nil, nil},
+		{2, 1, "▀", imageRed, nil},
+	}
+	for _, tc := range tests {
+		cell := c.CellAt(tc.x, tc.y)
+		if cell.Content != tc.content {
+			t.Errorf("(%d, %d): expected %q, got %q", tc.x, tc.y, tc.content, cell.Content)
+		}
+		if got := toRGBA(cell.Style.Fg); got != toRGBA(tc.fg) {
+			t.Errorf("(%d, %d): expected fg %v, got %v", tc.x, tc.y, tc.fg, got)
+		}
+		if got := toRGBA(cell.Style.Bg); got != toRGBA(tc.bg) {
+			t.Errorf("(%d, %d): expected bg %v, got %v", tc.x, tc.y, tc.bg, got)
+		}
+	}
+}
+
+func TestImageSize(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	tests := []struct {
+		name          string
+		width, height int
+		expected      image.Point
+	}{
+		{"natural", 0, 0, image.Pt(40, 10)},
+		{"width", 20, 0, image.Pt(20, 5)},
+		{"height", 0, 5, image.Pt(20, 5)},
+		{"both", 10, 10, image.Pt(10, 10)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b := NewImage(img).Size(tc.width, tc.height).Bounds()
+			if got := image.Pt(b.Dx(), b.Dy()); got != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestImageNil(t *testing.T) {
+	i := NewImage(nil).Size(4, 2)
+	if b := i.Bounds(); !b.Empty() {
+		t.Errorf("expected empty bounds, got %v", b)
+	}
+
+	// Nothing is drawn.
+	c := NewCanvas(4, 2).Compose(i)
+	if got := c.Render(); got != "\n" {
+		t.Errorf("expected a blank canvas, got %q", got)
+	}
+}
+
+func TestImageDownscale(t *testing.T) {
+	// Left half red, right half blue.
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for y := range 8 {
+		for x := range 8 {
+			if x < 4 {
+				img.Set(x, y, imageRed)
+			} else {
+				img.Set(x, y, imageBlue)
+			}
+		}
+	}
+
+	c := NewCanvas(2, 1)
+	c.Compose(NewImage(img).Size(2, 1))
+	if got := toRGBA(c.CellAt(0, 0).Style.Fg); got != imageRed {
+		t.Errorf("expected red, got %v", got)
+	}
+	if got := toRGBA(c.CellAt(1, 0).Style.Bg); got != imageBlue {
+		t.Errorf("expected blue, got %v", got)
+	}
+}
+
+func TestImageDither(t *testing.T) {
+	// A flat color that's between two ANSI colors.
+	gray := color.RGBA{R: 0x60, G: 0x60, B: 0x60, A: 0xff}
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for y := range 8 {
+		for x := range 8 {
+			img.Set(x, y, gray)
+		}
+	}
+
+	colors := func(i *Image) map[color.Color]int {
+		c := NewCanvas(8, 4)
+		c.Compose(i)
+		seen := map[color.Color]int{}
+		for y := range 4 {
+			for x := range 8 {
+				cell := c.CellAt(x, y)
+				for _, col := range []color.Color{cell.Style.Fg, cell.Style.Bg} {
+					if _, ok := col.(ansi.BasicColor); !ok {
+						t.Fatalf("expected an ANSI color, got %T", col)
+					}
+					seen[col]++
+				}
+			}
+		}
+		return seen
+	}
+
+	if got := colors(NewImage(img).Profile(colorprofile.ANSI)); len(got) != 1 {
+		t.Errorf("expected a single color without dithering, got %v", got)
+	}
+	if got := colors(NewImage(img).Profile(colorprofile.ANSI).Dither(true)); len(got) < 2 {
+		t.Errorf("expected dithering to mix colors, got %v", got)
+	}
+}
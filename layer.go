@@ -3,6 +3,7 @@ package lipgloss
 import (
 	"fmt"
 	"image"
+	"image/color"
 	"slices"
 
 	uv "github.com/charmbracelet/ultraviolet"
@@ -16,29 +17,135 @@ type Layer struct {
 	width, height int
 	x, y, z       int
 	layers        []*Layer
+	parent        *Layer
+	hidden        bool
+
+	// dirty is set on the top-most layer of a hierarchy whenever the
+	// hierarchy changes. A [Compositor] uses it to know when to re-flatten.
+	dirty bool
+
+	// sizeDirty is set on a layer and its ancestors whenever their size may
+	// have changed. The size is recalculated when it's needed.
+	sizeDirty bool
+
+	transparent bool
+	opacity     float64
+
+	clip             image.Rectangle
+	clipped          bool
+	scrollX, scrollY int
+
+	anchor *anchor
+	flip   bool
+
+	// Content sources other than a pre-rendered string.
+	drawable uv.Drawable
+	style    Style
+	styled   bool
+
+	// The explicit size of the Layer's content, if any.
+	size  image.Point
+	sized bool
+
+	// The measured size of the Layer's content.
+	measured image.Point
+
+	// The last rendered content of a styled Layer and the size it was
+	// rendered at.
+	rendered     string
+	renderedSize image.Point
 }
 
 // NewLayer creates a new [Layer] with the given content and optional child layers.
 func NewLayer(content string, layers ...*Layer) *Layer {
 	l := &Layer{
 		content: content,
+		opacity: 1,
 	}
+	l.measure()
 	l.AddLayers(layers...)
 	return l
 }
 
-// GetContent returns the content of the Layer.
+// NewDrawableLayer creates a new [Layer] whose content is drawn by the given
+// [uv.Drawable], such as a [Canvas], and optional child layers.
+//
+// If the drawable has a Bounds() method, like [Canvas] does, it's used to
+// determine the size of the Layer. Otherwise, the size must be set with
+// [Layer.Size].
+func NewDrawableLayer(drawable uv.Drawable, layers ...*Layer) *Layer {
+	l := &Layer{
+		drawable: drawable,
+		opacity:  1,
+	}
+	l.measure()
+	l.AddLayers(layers...)
+	return l
+}
+
+// NewStyleLayer creates a new [Layer] whose content is rendered with the given
+// [Style], and optional child layers.
+//
+// Rendering is deferred until the Layer is drawn. If the Layer has a size set
+// with [Layer.Size], the content is rendered at that size, so the style's
+// width and height are overridden.
+func NewStyleLayer(style Style, content string, layers ...*Layer) *Layer {
+	l := &Layer{
+		content: content,
+		style:   style,
+		styled:  true,
+		opacity: 1,
+	}
+	l.measure()
+	l.AddLayers(layers...)
+	return l
+}
+
+// GetContent returns the content of the Layer. For layers created with
+// [NewStyleLayer], this is the unstyled content.
 func (l *Layer) GetContent() string {
 	return l.content
 }
 
+// GetDrawable returns the [uv.Drawable] of the Layer, or nil if the Layer's
+// content is a string.
+func (l *Layer) GetDrawable() uv.Drawable {
+	return l.drawable
+}
+
+// GetStyle returns the [Style] of the Layer. Only layers created with
+// [NewStyleLayer] have a style.
+func (l *Layer) GetStyle() Style {
+	return l.style
+}
+
+// Size sets the size of the Layer's content, overriding its measured size.
+// Content is clipped to this size, and styled content is rendered at it.
+func (l *Layer) Size(width, height int) *Layer {
+	l.size = image.Pt(max(width, 0), max(height, 0))
+	l.sized = true
+	l.changed()
+	return l
+}
+
+// UnsetSize removes the explicit size of the Layer, so that its content is
+// measured again.
+func (l *Layer) UnsetSize() *Layer {
+	l.size = image.Point{}
+	l.sized = false
+	l.changed()
+	return l
+}
+
 // Width returns the width of the Layer.
 func (l *Layer) Width() int {
+	l.updateSize()
 	return l.width
 }
 
 // Height returns the height of the Layer.
 func (l *Layer) Height() int {
+	l.updateSize()
 	return l.height
 }
 
@@ -50,24 +157,28 @@ func (l *Layer) GetID() string {
 // ID sets the ID of the Layer.
 func (l *Layer) ID(id string) *Layer {
 	l.id = id
+	l.changed()
 	return l
 }
 
 // X sets the x-coordinate of the Layer relative to its parent.
 func (l *Layer) X(x int) *Layer {
 	l.x = x
+	l.changed()
 	return l
 }
 
 // Y sets the y-coordinate of the Layer relative to its parent.
 func (l *Layer) Y(y int) *Layer {
 	l.y = y
+	l.changed()
 	return l
 }
 
 // Z sets the z-index of the Layer relative to its parent.
 func (l *Layer) Z(z int) *Layer {
 	l.z = z
+	l.changed()
 	return l
 }
 
@@ -86,18 +197,276 @@ func (l *Layer) GetZ() int {
 	return l.z
 }
 
-// AddLayers adds child layers to the Layer.
+// Transparent sets whether unstyled spaces in the Layer's content are
+// transparent. Transparent cells are skipped when drawing, so whatever is
+// underneath the Layer shows through.
+func (l *Layer) Transparent(transparent bool) *Layer {
+	l.transparent = transparent
+	l.changed()
+	return l
+}
+
+// GetTransparent returns whether unstyled spaces in the Layer are transparent.
+func (l *Layer) GetTransparent() bool {
+	return l.transparent
+}
+
+// Opacity sets the opacity of the Layer using a 0-1 (clamped) float scale
+// 0 = invisible, 1 = opaque. The foreground and background colors of a
+// translucent Layer are blended with the cells below it. Blank cells let the
+// content below show through, tinted by the Layer's background color.
+//
+// Colors with an alpha channel, like the ones returned by [Alpha], are
+// blended according to their own alpha value as well.
+func (l *Layer) Opacity(opacity float64) *Layer {
+	l.opacity = clamp(opacity, 0, 1)
+	l.changed()
+	return l
+}
+
+// GetOpacity returns the opacity of the Layer.
+func (l *Layer) GetOpacity() float64 {
+	return l.opacity
+}
+
+// AddLayers adds child layers to the Layer. A layer can only have one parent,
+// so layers that already have a parent are moved to this Layer. It panics if
+// a layer is the Layer itself or one of its ancestors.
 func (l *Layer) AddLayers(layers ...*Layer) *Layer {
 	for i, layer := range layers {
 		if layer == nil {
 			panic(fmt.Sprintf("layer at index %d is nil", i))
 		}
+		for ancestor := l; ancestor != nil; ancestor = ancestor.parent {
+			if ancestor == layer {
+				panic(fmt.Sprintf("layer at index %d is an ancestor of the layer", i))
+			}
+		}
+		if parent := layer.parent; parent != nil {
+			// A layer can only have one parent.
+			parent.removeChild(layer)
+			parent.changed()
+		}
+		layer.parent = l
 		l.layers = append(l.layers, layer)
 	}
+	l.changed()
+	return l
+}
+
+// Remove removes the given child layers from the Layer. Layers that aren't
+// children of the Layer are ignored.
+func (l *Layer) Remove(layers ...*Layer) *Layer {
+	for _, layer := range layers {
+		if layer != nil && layer.parent == l {
+			l.removeChild(layer)
+		}
+	}
+	l.changed()
+	return l
+}
+
+// removeChild detaches a child layer without updating the Layer's size.
+func (l *Layer) removeChild(layer *Layer) {
+	l.layers = slices.DeleteFunc(l.layers, func(child *Layer) bool {
+		return child == layer
+	})
+	layer.parent = nil
+}
+
+// Parent returns the parent of the Layer, or nil if the Layer has no parent.
+func (l *Layer) Parent() *Layer {
+	return l.parent
+}
+
+// ReplaceContent replaces the content of the Layer. For layers created with
+// [NewStyleLayer], the new content is rendered with the Layer's style.
+func (l *Layer) ReplaceContent(content string) *Layer {
+	l.content = content
+	l.drawable = nil
+	l.measure()
+	l.changed()
+	return l
+}
+
+// ReplaceDrawable replaces the content of the Layer with the given
+// [uv.Drawable].
+func (l *Layer) ReplaceDrawable(drawable uv.Drawable) *Layer {
+	l.content = ""
+	l.drawable = drawable
+	l.styled = false
+	l.style = Style{}
+	l.measure()
+	l.changed()
+	return l
+}
+
+// measure measures the natural size of the Layer's content. Styled content is
+// rendered and kept around so it doesn't need to be rendered again when the
+// Layer is drawn without an explicit size.
+func (l *Layer) measure() {
+	l.rendered = ""
+	switch {
+	case l.drawable != nil:
+		l.measured = image.Point{}
+		if b, ok := l.drawable.(interface{ Bounds() uv.Rectangle }); ok {
+			l.measured = image.Pt(b.Bounds().Dx(), b.Bounds().Dy())
+		}
+	case l.styled:
+		l.rendered = l.style.Render(l.content)
+		l.measured = image.Pt(Width(l.rendered), Height(l.rendered))
+		l.renderedSize = l.measured
+	default:
+		l.measured = image.Pt(Width(l.content), Height(l.content))
+	}
+}
+
+// contentSize returns the size of the Layer's content.
+func (l *Layer) contentSize() (width, height int) {
+	if l.sized {
+		return l.size.X, l.size.Y
+	}
+	return l.measured.X, l.measured.Y
+}
+
+// styledContent returns the content of a styled Layer rendered at the given
+// size.
+func (l *Layer) styledContent(width, height int) string {
+	size := image.Pt(width, height)
+	if l.rendered != "" && l.renderedSize == size {
+		return l.rendered
+	}
+	l.rendered = l.style.
+		Width(width).
+		Height(height).
+		MaxWidth(width).
+		MaxHeight(height).
+		Render(l.content)
+	l.renderedSize = size
+	return l.rendered
+}
+
+// Hidden sets whether the Layer is hidden. Hidden layers and their
+// descendants are not drawn, can't be hit, and don't take up any space.
+func (l *Layer) Hidden(hidden bool) *Layer {
+	l.hidden = hidden
+	l.changed()
+	return l
+}
+
+// GetHidden returns whether the Layer is hidden.
+func (l *Layer) GetHidden() bool {
+	return l.hidden
+}
+
+// BringToFront moves the Layer in front of its siblings. If any sibling has a
+// higher z-index, the Layer's z-index is raised to match it.
+func (l *Layer) BringToFront() *Layer {
+	if l.parent == nil {
+		return l
+	}
+	siblings := l.parent.layers
+	for _, sibling := range siblings {
+		l.z = max(l.z, sibling.z)
+	}
+	i := slices.Index(siblings, l)
+	l.parent.layers = append(slices.Delete(siblings, i, i+1), l)
+	l.changed()
+	return l
+}
+
+// SendToBack moves the Layer behind its siblings. If any sibling has a lower
+// z-index, the Layer's z-index is lowered to match it.
+func (l *Layer) SendToBack() *Layer {
+	if l.parent == nil {
+		return l
+	}
+	siblings := l.parent.layers
+	for _, sibling := range siblings {
+		l.z = min(l.z, sibling.z)
+	}
+	i := slices.Index(siblings, l)
+	l.parent.layers = slices.Insert(slices.Delete(siblings, i, i+1), 0, l)
+	l.changed()
+	return l
+}
+
+// changed marks the size of the Layer and its ancestors as outdated, and the
+// hierarchy as dirty.
+func (l *Layer) changed() {
+	for layer := l; layer != nil; layer = layer.parent {
+		layer.sizeDirty = true
+		if layer.parent == nil {
+			layer.dirty = true
+		}
+	}
+}
+
+// Clip clips the Layer and all of its descendants to the given rectangle,
+// relative to the Layer's position. Anything outside of the rectangle is not
+// drawn and can't be hit. Nested clip rectangles are intersected.
+//
+// To clip child layers to the Layer's own content, use a rectangle starting
+// at (0, 0) with the size of the content.
+func (l *Layer) Clip(rect image.Rectangle) *Layer {
+	l.clip = rect.Canon()
+	l.clipped = true
+	l.changed()
+	return l
+}
+
+// UnsetClip removes the clip rectangle of the Layer.
+func (l *Layer) UnsetClip() *Layer {
+	l.clip = image.Rectangle{}
+	l.clipped = false
+	l.changed()
+	return l
+}
+
+// GetClip returns the clip rectangle of the Layer relative to its position,
+// and whether the Layer is clipped at all.
+func (l *Layer) GetClip() (image.Rectangle, bool) {
+	return l.clip, l.clipped
+}
+
+// ScrollX sets the horizontal scroll offset of the Layer. Child layers are
+// moved to the left by the given amount, while the Layer's own content stays
+// in place. Combine it with [Layer.Clip] to build scrollable viewports.
+func (l *Layer) ScrollX(x int) *Layer {
+	l.scrollX = x
+	l.changed()
+	return l
+}
+
+// ScrollY sets the vertical scroll offset of the Layer. Child layers are
+// moved up by the given amount, while the Layer's own content stays in place.
+// Combine it with [Layer.Clip] to build scrollable viewports.
+func (l *Layer) ScrollY(y int) *Layer {
+	l.scrollY = y
+	l.changed()
+	return l
+}
+
+// GetScrollX returns the horizontal scroll offset of the Layer.
+func (l *Layer) GetScrollX() int {
+	return l.scrollX
+}
+
+// GetScrollY returns the vertical scroll offset of the Layer.
+func (l *Layer) GetScrollY() int {
+	return l.scrollY
+}
+
+// updateSize recalculates the width and height of the Layer, if they may have
+// changed.
+func (l *Layer) updateSize() {
+	if !l.sizeDirty {
+		return
+	}
+	l.sizeDirty = false
 	area := l.boundsWithOffset(0, 0)
 	l.width = area.Dx()
 	l.height = area.Dy()
-	return l
 }
 
 // GetLayer returns a descendant layer by its ID, or nil if not found.
@@ -134,14 +503,31 @@ func (l *Layer) boundsWithOffset(parentX, parentY int) image.Rectangle {
 	absX := l.x + parentX
 	absY := l.y + parentY
 
-	width, height := Width(l.content), Height(l.content)
+	width, height := l.contentSize()
 	bounds := image.Rectangle{
 		Min: image.Pt(absX, absY),
 		Max: image.Pt(absX+width, absY+height),
 	}
 
 	for _, child := range l.layers {
-		bounds = bounds.Union(child.boundsWithOffset(absX, absY))
+		switch {
+		case child.hidden, child.anchored():
+			continue
+		case child.placed():
+			// Placed children are positioned inside the Layer's content and
+			// aren't scrolled.
+			cw, ch := child.contentSize()
+			bounds = bounds.Union(child.boundsWithOffset(
+				absX+placeIn(width, cw, child.anchor.hPos),
+				absY+placeIn(height, ch, child.anchor.vPos),
+			))
+		default:
+			bounds = bounds.Union(child.boundsWithOffset(absX-l.scrollX, absY-l.scrollY))
+		}
+	}
+
+	if l.clipped {
+		bounds = bounds.Intersect(l.clip.Add(image.Pt(absX, absY)))
 	}
 
 	return bounds
@@ -150,9 +536,161 @@ func (l *Layer) boundsWithOffset(parentX, parentY int) image.Rectangle {
 var _ uv.Drawable = (*Layer)(nil)
 
 // Draw draws the content of the layer on the screen at the specified area.
+// Hidden layers are not drawn.
 func (l *Layer) Draw(scr uv.Screen, area uv.Rectangle) {
-	content := uv.NewStyledString(l.content)
-	content.Draw(scr, area)
+	if l.hidden {
+		return
+	}
+	if !l.transparent && l.opacity >= 1 {
+		l.drawContent(scr, area)
+		return
+	}
+
+	// Draw the content off-screen first so it can be composited with the
+	// cells that are already on the screen.
+	buf := layerBuffer{uv.NewBuffer(area.Dx(), area.Dy()), scr.WidthMethod()}
+	l.drawContent(buf, buf.Bounds())
+	l.composite(scr, area, buf.Buffer)
+}
+
+// drawContent draws the content of the Layer, whatever its source, on the
+// screen at the specified area.
+func (l *Layer) drawContent(scr uv.Screen, area uv.Rectangle) {
+	switch {
+	case l.drawable != nil:
+		// Clear the area first so that drawables are as opaque as strings.
+		for y := area.Min.Y; y < area.Max.Y; y++ {
+			for x := area.Min.X; x < area.Max.X; x++ {
+				scr.SetCell(x, y, nil)
+			}
+		}
+		l.drawable.Draw(clipScreen{scr, area.Intersect(scr.Bounds())}, area)
+	case l.styled:
+		uv.NewStyledString(l.styledContent(area.Dx(), area.Dy())).Draw(scr, area)
+	default:
+		uv.NewStyledString(l.content).Draw(scr, area)
+	}
+}
+
+// layerBuffer is an off-screen [uv.Screen] used to composite a [Layer].
+type layerBuffer struct {
+	*uv.Buffer
+	method uv.WidthMethod
+}
+
+// WidthMethod implements [uv.Screen].
+func (b layerBuffer) WidthMethod() uv.WidthMethod {
+	return b.method
+}
+
+// composite draws the cells of buf onto the screen at the given area, applying
+// the Layer's transparency and opacity.
+func (l *Layer) composite(scr uv.Screen, area uv.Rectangle, buf *uv.Buffer) {
+	if l.opacity <= 0 {
+		return
+	}
+	for y := 0; y < buf.Height(); y++ {
+		for x := 0; x < buf.Width(); {
+			cell := buf.CellAt(x, y)
+			if cell == nil || cell.IsZero() {
+				// Wide cell placeholder.
+				x++
+				continue
+			}
+			width := max(cell.Width, 1)
+			if l.transparent && isBlankCell(cell) {
+				x += width
+				continue
+			}
+			pos := area.Min.Add(image.Pt(x, y))
+			below := scr.CellAt(pos.X, pos.Y)
+			scr.SetCell(pos.X, pos.Y, compositeCell(cell, below, l.opacity))
+			x += width
+		}
+	}
+}
+
+// isBlankCell returns whether the cell is an unstyled space.
+func isBlankCell(c *uv.Cell) bool {
+	return c.Content == " " && c.Style.IsZero() && c.Link.IsZero()
+}
+
+// compositeCell blends cell over the cell below it with the given opacity.
+func compositeCell(cell, below *uv.Cell, opacity float64) *uv.Cell {
+	fgAlpha := opacity * colorAlpha(cell.Style.Fg)
+	bgAlpha := opacity * colorAlpha(cell.Style.Bg)
+	if below == nil {
+		below = &uv.EmptyCell
+	}
+
+	// Blank cells let the content below show through, tinted by the
+	// background of the cell on top.
+	if cell.Content == " " && bgAlpha < 1 && below.Width == 1 {
+		c := below.Clone()
+		if cell.Style.Bg != nil {
+			c.Style.Fg = blendColor(below.Style.Fg, cell.Style.Bg, bgAlpha)
+		}
+		c.Style.Bg = blendColor(below.Style.Bg, cell.Style.Bg, bgAlpha)
+		return c
+	}
+
+	c := cell.Clone()
+	c.Style.Bg = blendColor(below.Style.Bg, cell.Style.Bg, bgAlpha)
+	if cell.Style.Fg != nil {
+		c.Style.Fg = blendColor(c.Style.Bg, cell.Style.Fg, fgAlpha)
+	}
+	return c
+}
+
+// colorAlpha returns the alpha value of a color on a 0-1 scale. A nil color is
+// considered opaque.
+func colorAlpha(c color.Color) float64 {
+	if c == nil {
+		return 1
+	}
+	if _, ok := c.(NoColor); ok {
+		return 1
+	}
+	_, _, _, a := c.RGBA()
+	return float64(a) / 0xffff
+}
+
+// blendColor blends top over bottom using the given alpha value in the 0-1
+// range. If top is nil, bottom is returned. If bottom is nil, top is returned
+// as an opaque color, since there is nothing to blend it with.
+func blendColor(bottom, top color.Color, alpha float64) color.Color {
+	if top == nil {
+		return bottom
+	}
+	if bottom == nil || alpha >= 1 {
+		return opaque(top)
+	}
+	br, bg, bb := straightRGB(bottom)
+	tr, tg, tb := straightRGB(top)
+	mix := func(b, t uint8) uint8 {
+		return uint8(float64(b)*(1-alpha) + float64(t)*alpha + 0.5) //nolint:mnd
+	}
+	return color.RGBA{R: mix(br, tr), G: mix(bg, tg), B: mix(bb, tb), A: 0xff}
+}
+
+// opaque returns the color with its alpha channel removed. Colors that are
+// already opaque are returned as is.
+func opaque(c color.Color) color.Color {
+	if colorAlpha(c) >= 1 {
+		return c
+	}
+	r, g, b := straightRGB(c)
+	return color.RGBA{R: r, G: g, B: b, A: 0xff}
+}
+
+// straightRGB returns the non-premultiplied 8-bit RGB components of a color.
+// [color.RGBA] values are read as is since that's what [Alpha] returns.
+func straightRGB(c color.Color) (r, g, b uint8) {
+	if rgba, ok := c.(color.RGBA); ok {
+		return rgba.R, rgba.G, rgba.B
+	}
+	n, _ := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return n.R, n.G, n.B
 }
 
 // LayerHit represents the result of a hit test on a [Layer].
@@ -160,6 +698,7 @@ type LayerHit struct {
 	id     string
 	layer  *Layer
 	bounds image.Rectangle
+	local  image.Point
 }
 
 // Empty returns true if the LayerHit represents no hit.
@@ -182,6 +721,12 @@ func (lh LayerHit) Bounds() image.Rectangle {
 	return lh.bounds
 }
 
+// Local returns the hit point relative to the top-left corner of the hit
+// Layer.
+func (lh LayerHit) Local() image.Point {
+	return lh.local
+}
+
 // Compositor manages the composition of layers. It flattens a layer hierarchy
 // once and provides efficient drawing and hit testing operations. All computation
 // related to layers happens in the Compositor.
@@ -189,15 +734,32 @@ type Compositor struct {
 	root   *Layer
 	layers []compositeLayer
 	index  map[string]*Layer
+	lookup map[*Layer]compositeLayer
 	bounds image.Rectangle
+
+	handlers map[string]map[LayerEventType]LayerEventHandler
+	hovered  []*Layer
+	hoverHit LayerHit // the target of the hovered layers
+
+	// Anchor resolution state.
+	origins   map[*Layer]image.Point
+	resolving map[*Layer]bool
+	scene     image.Rectangle
+
+	camera    image.Rectangle
+	hasCamera bool
 }
 
 // compositeLayer holds a flattened layer with its calculated absolute position and bounds.
 type compositeLayer struct {
-	layer  *Layer
-	absX   int
-	absY   int
-	bounds image.Rectangle
+	layer   *Layer
+	parent  *Layer
+	absX    int
+	absY    int
+	bounds  image.Rectangle
+	clip    image.Rectangle // absolute clip rectangle, if clipped
+	clipped bool
+	visible image.Rectangle // bounds intersected with the clip rectangle
 }
 
 // NewCompositor creates a new Compositor with an internal root layer. Optional
@@ -225,81 +787,182 @@ func (c *Compositor) AddLayers(layers ...*Layer) *Compositor {
 func (c *Compositor) flatten() {
 	c.layers = nil
 	c.index = make(map[string]*Layer)
-	c.flattenRecursive(c.root, 0, 0)
-
-	// Sort by absolute z-index (lowest to highest for drawing)
-	slices.SortFunc(c.layers, func(a, b compositeLayer) int {
+	c.lookup = make(map[*Layer]compositeLayer)
+	c.bounds = image.Rectangle{}
+	c.indexRecursive(c.root)
+	c.resolveOrigins()
+	c.flattenRecursive(c.root, nil, image.Rectangle{}, false)
+	c.root.dirty = false
+
+	// Sort by absolute z-index (lowest to highest for drawing). Layers with
+	// the same z-index keep their order in the hierarchy.
+	slices.SortStableFunc(c.layers, func(a, b compositeLayer) int {
 		return a.layer.z - b.layer.z
 	})
 
 	// Calculate overall bounds
 	if len(c.layers) > 0 {
-		c.bounds = c.layers[0].bounds
+		c.bounds = c.layers[0].visible
 		for i := 1; i < len(c.layers); i++ {
-			c.bounds = c.bounds.Union(c.layers[i].bounds)
+			c.bounds = c.bounds.Union(c.layers[i].visible)
 		}
 	}
 }
 
-// flattenRecursive recursively collects all layers with their absolute positions.
-func (c *Compositor) flattenRecursive(layer *Layer, parentX, parentY int) {
-	absX := layer.x + parentX
-	absY := layer.y + parentY
+// flattenRecursive recursively collects all visible layers with their
+// absolute positions. The clip rectangle of the parent, if any, is inherited
+// by its descendants.
+func (c *Compositor) flattenRecursive(layer, parent *Layer, clip image.Rectangle, clipped bool) {
+	if layer.hidden {
+		return
+	}
+
+	origin := c.origin(layer)
+	absX, absY := origin.X, origin.Y
 
-	width, height := Width(layer.content), Height(layer.content)
+	width, height := layer.contentSize()
 	bounds := image.Rectangle{
 		Min: image.Pt(absX, absY),
 		Max: image.Pt(absX+width, absY+height),
 	}
 
-	c.layers = append(c.layers, compositeLayer{
-		layer:  layer,
-		absX:   absX,
-		absY:   absY,
-		bounds: bounds,
-	})
+	if layer.clipped {
+		layerClip := layer.clip.Add(image.Pt(absX, absY))
+		if clipped {
+			clip = clip.Intersect(layerClip)
+		} else {
+			clip = layerClip
+		}
+		clipped = true
+	}
+
+	visible := bounds
+	if clipped {
+		visible = bounds.Intersect(clip)
+	}
+
+	cl := compositeLayer{
+		layer:   layer,
+		parent:  parent,
+		absX:    absX,
+		absY:    absY,
+		bounds:  bounds,
+		clip:    clip,
+		clipped: clipped,
+		visible: visible,
+	}
+	c.layers = append(c.layers, cl)
+	c.lookup[layer] = cl
+
+	for _, child := range layer.layers {
+		c.flattenRecursive(child, layer, clip, clipped)
+	}
+}
 
-	// Index layer by ID if it has one
+// indexRecursive indexes a layer and its descendants by ID. Hidden layers are
+// indexed too, so they can still be retrieved.
+func (c *Compositor) indexRecursive(layer *Layer) {
 	if layer.id != "" {
 		c.index[layer.id] = layer
 	}
-
 	for _, child := range layer.layers {
-		c.flattenRecursive(child, absX, absY)
+		c.indexRecursive(child)
+	}
+}
+
+// update re-flattens the layer hierarchy if it changed since the last time
+// it was flattened.
+func (c *Compositor) update() {
+	if c.root.dirty {
+		c.flatten()
 	}
 }
 
 // Bounds returns the overall bounds of all layers in the compositor.
 func (c *Compositor) Bounds() image.Rectangle {
+	c.update()
 	return c.bounds
 }
 
-// Draw draws all layers onto the given [uv.Screen] in z-index order.
+// Draw draws all layers onto the given [uv.Screen] in z-index order. The
+// top-left corner of the compositor's view, see [Compositor.View], is drawn
+// at the top-left corner of the area, and anything outside of the area is
+// clipped.
 func (c *Compositor) Draw(scr uv.Screen, area image.Rectangle) {
+	c.update()
+	view := c.View()
+	offset := area.Min.Sub(view.Min)
 	for _, cl := range c.layers {
-		if cl.bounds.Overlaps(area) {
-			cl.layer.Draw(scr, cl.bounds)
+		if !cl.visible.Overlaps(view) {
+			continue
 		}
+		clip := area.Intersect(view.Add(offset))
+		if cl.clipped {
+			clip = clip.Intersect(cl.clip.Add(offset))
+		}
+		cl.layer.Draw(clipScreen{scr, clip}, cl.bounds.Add(offset))
+	}
+}
+
+// clipScreen is a [uv.Screen] that ignores cells set outside of its clip
+// rectangle.
+type clipScreen struct {
+	uv.Screen
+	clip image.Rectangle
+}
+
+// SetCell implements [uv.Screen].
+func (s clipScreen) SetCell(x, y int, cell *uv.Cell) {
+	if image.Pt(x, y).In(s.clip) {
+		s.Screen.SetCell(x, y, cell)
 	}
 }
 
 // Hit performs a hit test at the given (x, y) coordinates. If a layer is hit,
 // it returns the ID of the top-most layer at that point. Layers with empty IDs
-// are ignored. If no layer is hit, it returns an empty [LayerHit].
+// are ignored, as well as the parts of layers that are clipped. If no layer is
+// hit, it returns an empty [LayerHit].
+//
+// If a camera is set, the coordinates are relative to the camera. Otherwise,
+// they're scene coordinates.
 func (c *Compositor) Hit(x, y int) LayerHit {
-	var hit LayerHit
-	pt := image.Pt(x, y)
+	c.update()
+	pt := c.scenePoint(x, y)
 	// Check from highest z to lowest (reverse order)
 	for i := len(c.layers) - 1; i >= 0; i-- {
 		cl := c.layers[i]
-		if cl.layer.id != "" && pt.In(cl.bounds) {
-			hit.id = cl.layer.id
-			hit.layer = cl.layer
-			hit.bounds = cl.bounds
-			return hit
+		if cl.layer.id != "" && pt.In(cl.visible) {
+			return cl.hit(pt)
+		}
+	}
+	return LayerHit{}
+}
+
+// HitAll performs a hit test at the given (x, y) coordinates and returns all
+// layers at that point, from the top-most to the bottom-most one. Unlike
+// [Compositor.Hit], layers with empty IDs are included. Clipped parts of
+// layers are ignored. Coordinates are interpreted as in [Compositor.Hit].
+func (c *Compositor) HitAll(x, y int) []LayerHit {
+	c.update()
+	var hits []LayerHit
+	pt := c.scenePoint(x, y)
+	for i := len(c.layers) - 1; i >= 0; i-- {
+		cl := c.layers[i]
+		if pt.In(cl.visible) {
+			hits = append(hits, cl.hit(pt))
 		}
 	}
-	return hit
+	return hits
+}
+
+// hit returns a [LayerHit] for the given absolute point.
+func (cl compositeLayer) hit(pt image.Point) LayerHit {
+	return LayerHit{
+		id:     cl.layer.id,
+		layer:  cl.layer,
+		bounds: cl.bounds,
+		local:  pt.Sub(cl.bounds.Min),
+	}
 }
 
 // GetLayer returns a layer by its ID, or nil if not found.
@@ -308,20 +971,22 @@ func (c *Compositor) GetLayer(id string) *Layer {
 	if id == "" {
 		return nil
 	}
+	c.update()
 	return c.index[id]
 }
 
-// Refresh re-flattens the layer hierarchy. Call this after modifying the layer
-// tree structure or positions to update the compositor's internal state.
+// Refresh re-flattens the layer hierarchy. Changes made through [Layer]
+// methods are tracked and picked up automatically, so calling it is only
+// needed to force a re-flatten.
 func (c *Compositor) Refresh() {
 	c.flatten()
 }
 
-// Render renders the compositor into a styled string. This is a helper
-// function that creates a temporary canvas, draws the compositor onto it, and
-// returns the resulting string.
+// Render renders the compositor's view, see [Compositor.View], into a styled
+// string. This is a helper function that creates a temporary canvas, draws
+// the compositor onto it, and returns the resulting string.
 func (c *Compositor) Render() string {
-	width, height := c.bounds.Dx(), c.bounds.Dy()
-	canvas := NewCanvas(width, height)
+	view := c.View()
+	canvas := NewCanvas(view.Dx(), view.Dy())
 	return canvas.Compose(c).Render()
 }
@@ -0,0 +1,427 @@
+package lipgloss
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+
+	uv "github.com/charmbracelet/ultraviolet"
+)
+
+func TestLayerTransparent(t *testing.T) {
+	base := NewLayer(strings.Join([]string{
+		"aaaa",
+		"aaaa",
+	}, "\n"))
+	top := NewLayer("b  b").Transparent(true)
+
+	got := NewCompositor(base, top).Render()
+	expected := strings.Join([]string{
+		"baab",
+		"aaaa",
+	}, "\n")
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	// Opaque layers hide what's underneath.
+	top.Transparent(false)
+	got = NewCompositor(base, top).Render()
+	expected = strings.Join([]string{
+		"b  b",
+		"aaaa",
+	}, "\n")
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestLayerOpacity(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+	blue := color.RGBA{B: 0xff, A: 0xff}
+
+	base := NewLayer(NewStyle().Background(red).Render("ab"))
+	top := NewLayer(NewStyle().Background(blue).Render(" c")).Opacity(0.5)
+
+	canvas := NewCanvas(2, 1)
+	canvas.Compose(NewCompositor(base, top))
+
+	// The blank cell lets the content below show through.
+	cell := canvas.CellAt(0, 0)
+	if cell.Content != "a" {
+		t.Errorf("expected content %q, got %q", "a", cell.Content)
+	}
+	expected := color.RGBA{R: 0x80, B: 0x80, A: 0xff}
+	if got := toRGBA(cell.Style.Bg); got != expected {
+		t.Errorf("expected background %v, got %v", expected, got)
+	}
+
+	// Glyphs on top replace the content below.
+	cell = canvas.CellAt(1, 0)
+	if cell.Content != "c" {
+		t.Errorf("expected content %q, got %q", "c", cell.Content)
+	}
+	if got := toRGBA(cell.Style.Bg); got != expected {
+		t.Errorf("expected background %v, got %v", expected, got)
+	}
+}
+
+func TestLayerOpacityNoBackground(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+
+	base := NewLayer(NewStyle().Background(red).Render("a"))
+	top := NewLayer(" ").Opacity(0.5)
+
+	canvas := NewCanvas(1, 1)
+	canvas.Compose(NewCompositor(base, top))
+
+	// A nil background doesn't change the cell below.
+	cell := canvas.CellAt(0, 0)
+	if cell.Content != "a" {
+		t.Errorf("expected content %q, got %q", "a", cell.Content)
+	}
+	if got := toRGBA(cell.Style.Bg); got != red {
+		t.Errorf("expected background %v, got %v", red, got)
+	}
+}
+
+func TestCompositeCellAlpha(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+	blue := color.RGBA{B: 0xff, A: 0xff}
+
+	below := &uv.Cell{Content: "a", Width: 1, Style: uv.Style{Bg: red}}
+	cell := &uv.Cell{Content: "b", Width: 1, Style: uv.Style{Bg: Alpha(blue, 0.5)}}
+
+	got := compositeCell(cell, below, 1)
+	if got.Content != "b" {
+		t.Errorf("expected content %q, got %q", "b", got.Content)
+	}
+	// Alpha(blue, 0.5) has an alpha value of 127/255.
+	expected := color.RGBA{R: 0x80, B: 0x7f, A: 0xff}
+	if bg := toRGBA(got.Style.Bg); bg != expected {
+		t.Errorf("expected background %v, got %v", expected, bg)
+	}
+
+	// Without a cell below, colors are made opaque.
+	got = compositeCell(cell, nil, 1)
+	if bg := toRGBA(got.Style.Bg); bg != blue {
+		t.Errorf("expected background %v, got %v", blue, bg)
+	}
+}
+
+func TestLayerOpacityZero(t *testing.T) {
+	base := NewLayer("aa")
+	top := NewLayer("bb").Opacity(0)
+
+	if got := NewCompositor(base, top).Render(); got != "aa" {
+		t.Errorf("expected %q, got %q", "aa", got)
+	}
+}
+
+func TestLayerOpacityMutation(t *testing.T) {
+	base := NewLayer("aa")
+	top := NewLayer("b ")
+	comp := NewCompositor(base, top)
+
+	if got := comp.Render(); got != "b" {
+		t.Errorf("expected %q, got %q", "b", got)
+	}
+
+	// Changes are picked up after the layers were flattened.
+	top.Opacity(0)
+	if got := comp.Render(); got != "aa" {
+		t.Errorf("expected %q, got %q", "aa", got)
+	}
+
+	top.Opacity(1).Transparent(true)
+	if got := comp.Render(); got != "ba" {
+		t.Errorf("expected %q, got %q", "ba", got)
+	}
+}
+
+func toRGBA(c color.Color) color.RGBA {
+	if c == nil {
+		return color.RGBA{}
+	}
+	r, g, b, a := c.RGBA()
+	return color.RGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: uint8(a >> 8)}
+}
+
+func TestLayerClip(t *testing.T) {
+	pane := NewLayer(strings.Join([]string{
+		"....",
+		"....",
+	}, "\n")).Clip(image.Rect(0, 0, 4, 2)).AddLayers(
+		NewLayer(strings.Join([]string{
+			"abcdef",
+			"ghijkl",
+			"mnopqr",
+		}, "\n")).ID("content").X(1),
+	)
+
+	if pane.Width() != 4 || pane.Height() != 2 {
+		t.Errorf("expected size 4x2, got %dx%d", pane.Width(), pane.Height())
+	}
+
+	got := NewCompositor(pane).Render()
+	expected := strings.Join([]string{
+		".abc",
+		".ghi",
+	}, "\n")
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestLayerScroll(t *testing.T) {
+	content := NewLayer(strings.Join([]string{
+		"abc",
+		"def",
+		"ghi",
+	}, "\n")).ID("content")
+	pane := NewLayer("").Clip(image.Rect(0, 0, 2, 2)).AddLayers(content)
+
+	pane.ScrollX(1).ScrollY(1)
+	comp := NewCompositor(pane)
+	got := comp.Render()
+	expected := strings.Join([]string{
+		"ef",
+		"hi",
+	}, "\n")
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	// Hit testing respects the clip rectangle.
+	if hit := comp.Hit(0, 0); hit.ID() != "content" {
+		t.Errorf("expected hit on %q, got %q", "content", hit.ID())
+	}
+	if hit := comp.Hit(2, 0); !hit.Empty() {
+		t.Errorf("expected no hit, got %q", hit.ID())
+	}
+	if hit := comp.Hit(-1, -1); !hit.Empty() {
+		t.Errorf("expected no hit, got %q", hit.ID())
+	}
+}
+
+func TestLayerNestedClip(t *testing.T) {
+	inner := NewLayer("abcd").ID("inner").Clip(image.Rect(0, 0, 3, 1))
+	outer := NewLayer("").Clip(image.Rect(0, 0, 2, 1)).AddLayers(inner)
+
+	comp := NewCompositor(outer)
+	if got := comp.Render(); got != "ab" {
+		t.Errorf("expected %q, got %q", "ab", got)
+	}
+	if hit := comp.Hit(2, 0); !hit.Empty() {
+		t.Errorf("expected no hit, got %q", hit.ID())
+	}
+}
+
+func TestLayerMutation(t *testing.T) {
+	a := NewLayer("aaa").ID("a")
+	b := NewLayer("bb").ID("b")
+	c := NewLayer("c").ID("c")
+	comp := NewCompositor(a, b, c)
+
+	if got := comp.Render(); got != "cba" {
+		t.Errorf("expected %q, got %q", "cba", got)
+	}
+
+	// Changes are picked up without calling Refresh.
+	a.BringToFront()
+	if got := comp.Render(); got != "aaa" {
+		t.Errorf("expected %q, got %q", "aaa", got)
+	}
+
+	a.SendToBack()
+	b.ReplaceContent("BB")
+	if got := comp.Render(); got != "cBa" {
+		t.Errorf("expected %q, got %q", "cBa", got)
+	}
+
+	c.Hidden(true)
+	if got := comp.Render(); got != "BBa" {
+		t.Errorf("expected %q, got %q", "BBa", got)
+	}
+	if hit := comp.Hit(0, 0); hit.ID() != "b" {
+		t.Errorf("expected hit on %q, got %q", "b", hit.ID())
+	}
+	if comp.GetLayer("c") != c {
+		t.Error("expected hidden layers to be retrievable by ID")
+	}
+
+	c.Parent().Remove(c)
+	c.Hidden(false)
+	if c.Parent() != nil {
+		t.Error("expected removed layer to have no parent")
+	}
+	if got := comp.Render(); got != "BBa" {
+		t.Errorf("expected %q, got %q", "BBa", got)
+	}
+	if comp.GetLayer("c") != nil {
+		t.Error("expected removed layer not to be retrievable")
+	}
+}
+
+func TestLayerReparent(t *testing.T) {
+	child := NewLayer("x").ID("child")
+	left := NewLayer("..", child).ID("left")
+	right := NewLayer("..").ID("right").X(3)
+	comp := NewCompositor(left, right)
+
+	if got := comp.Render(); got != "x. .." {
+		t.Errorf("expected %q, got %q", "x. ..", got)
+	}
+
+	right.AddLayers(child.X(1))
+	if child.Parent() != right {
+		t.Error("expected child to be moved to the new parent")
+	}
+	if got := comp.Render(); got != ".. .x" {
+		t.Errorf("expected %q, got %q", ".. .x", got)
+	}
+	if hit := comp.Hit(4, 0); hit.ID() != "child" {
+		t.Errorf("expected hit on %q, got %q", "child", hit.ID())
+	}
+}
+
+func TestLayerSizeTracking(t *testing.T) {
+	child := NewLayer("abc")
+	parent := NewLayer("a", child)
+	if parent.Width() != 3 {
+		t.Errorf("expected width 3, got %d", parent.Width())
+	}
+
+	child.X(2)
+	if parent.Width() != 5 {
+		t.Errorf("expected width 5, got %d", parent.Width())
+	}
+
+	child.Hidden(true)
+	if parent.Width() != 1 {
+		t.Errorf("expected width 1, got %d", parent.Width())
+	}
+}
+
+func TestLayerSizeReparent(t *testing.T) {
+	child := NewLayer("abcd")
+	left := NewLayer("a", child)
+	right := NewLayer("b")
+	if left.Width() != 4 {
+		t.Errorf("expected width 4, got %d", left.Width())
+	}
+
+	// Both parents are resized when a child moves.
+	right.AddLayers(child)
+	if left.Width() != 1 || right.Width() != 4 {
+		t.Errorf("expected widths 1 and 4, got %d and %d", left.Width(), right.Width())
+	}
+}
+
+func TestLayerCycle(t *testing.T) {
+	a := NewLayer("a")
+	b := NewLayer("b")
+	c := NewLayer("c")
+	a.AddLayers(b)
+	b.AddLayers(c)
+
+	for _, tc := range []struct {
+		name          string
+		parent, child *Layer
+	}{
+		{"self", a, a},
+		{"parent", b, a},
+		{"grandparent", c, a},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected adding an ancestor to panic")
+				}
+			}()
+			tc.parent.AddLayers(tc.child)
+		})
+	}
+	if a.Parent() != nil || b.Parent() != a || c.Parent() != b {
+		t.Error("expected the hierarchy to be unchanged")
+	}
+}
+
+func TestDrawableLayer(t *testing.T) {
+	canvas := NewCanvas(3, 2)
+	canvas.Compose(NewLayer("abc\ndef"))
+
+	layer := NewDrawableLayer(canvas).X(1)
+	if layer.Width() != 3 || layer.Height() != 2 {
+		t.Errorf("expected size 3x2, got %dx%d", layer.Width(), layer.Height())
+	}
+
+	got := NewCompositor(NewLayer("....\n...."), layer).Render()
+	expected := strings.Join([]string{
+		".abc",
+		".def",
+	}, "\n")
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	// Drawables without bounds need an explicit size, and are clipped to
+	// it.
+	fill := uv.DrawableFunc(func(scr uv.Screen, area uv.Rectangle) {
+		for y := area.Min.Y - 1; y <= area.Max.Y; y++ {
+			for x := area.Min.X - 1; x <= area.Max.X; x++ {
+				scr.SetCell(x, y, uv.NewCell(scr.WidthMethod(), "#"))
+			}
+		}
+	})
+	layer = NewDrawableLayer(fill).X(1).Y(1)
+	if layer.Width() != 0 || layer.Height() != 0 {
+		t.Errorf("expected size 0x0, got %dx%d", layer.Width(), layer.Height())
+	}
+	layer.Size(2, 1)
+
+	got = NewCompositor(NewLayer("....\n....\n...."), layer).Render()
+	expected = strings.Join([]string{
+		"....",
+		".##.",
+		"....",
+	}, "\n")
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestStyleLayer(t *testing.T) {
+	style := NewStyle().Border(NormalBorder())
+
+	layer := NewStyleLayer(style, "hi")
+	if layer.Width() != 4 || layer.Height() != 3 {
+		t.Errorf("expected size 4x3, got %dx%d", layer.Width(), layer.Height())
+	}
+	if got, expected := NewCompositor(layer).Render(), style.Render("hi"); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	// Styled content is rendered at the size of the layer.
+	layer.Size(6, 4)
+	got := NewCompositor(layer).Render()
+	expected := strings.Join([]string{
+		"┌────┐",
+		"│hi  │",
+		"│    │",
+		"└────┘",
+	}, "\n")
+	if got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	layer.ReplaceContent("yo")
+	if got := layer.GetContent(); got != "yo" {
+		t.Errorf("expected content %q, got %q", "yo", got)
+	}
+	expected = strings.Replace(expected, "hi", "yo", 1)
+	if got := NewCompositor(layer).Render(); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
@@ -0,0 +1,296 @@
+package lipgloss
+
+import (
+	"image/color"
+
+	uv "github.com/charmbracelet/ultraviolet"
+)
+
+// PixelMode determines how the pixels of a [PixelGrid] map to terminal cells.
+type PixelMode int
+
+// Pixel modes.
+const (
+	// BraillePixels uses braille patterns, with 2x4 pixels per cell.
+	BraillePixels PixelMode = iota
+
+	// QuadrantPixels uses quadrant block elements, with 2x2 pixels per cell.
+	QuadrantPixels
+
+	// HalfBlockPixels uses half block elements, with 1x2 pixels per cell.
+	HalfBlockPixels
+
+	// SextantPixels uses sextant block elements, with 2x3 pixels per cell.
+	// Sextants are part of the Symbols for Legacy Computing block, which
+	// isn't supported by all fonts.
+	SextantPixels
+)
+
+// cellSize returns the number of pixels per cell, horizontally and
+// vertically.
+func (m PixelMode) cellSize() (width, height int) {
+	switch m {
+	case QuadrantPixels:
+		return 2, 2 //nolint:mnd
+	case HalfBlockPixels:
+		return 1, 2 //nolint:mnd
+	case SextantPixels:
+		return 2, 3 //nolint:mnd
+	default:
+		return 2, 4 //nolint:mnd
+	}
+}
+
+// PixelGrid is a grid of pixels that's drawn onto terminal cells using block
+// elements or braille patterns, depending on its [PixelMode]. Each cell has a
+// single color, which is the color of the last pixel set in it.
+//
+// It implements [uv.Drawable], so it can be composed onto a [Canvas] or used
+// as the content of a [Layer] with [NewDrawableLayer]. Cells without any
+// pixels set are not drawn, so whatever is underneath shows through.
+type PixelGrid struct {
+	mode          PixelMode
+	width, height int // in cells
+	cellW, cellH  int // pixels per cell
+	pixels        []bool
+	colors        []color.Color
+}
+
+var _ uv.Drawable = (*PixelGrid)(nil)
+
+// NewPixelGrid creates a new [PixelGrid] that's the given number of cells
+// wide and tall.
+func NewPixelGrid(width, height int, mode PixelMode) *PixelGrid {
+	width, height = max(width, 0), max(height, 0)
+	cellW, cellH := mode.cellSize()
+	return &PixelGrid{
+		mode:   mode,
+		width:  width,
+		height: height,
+		cellW:  cellW,
+		cellH:  cellH,
+		pixels: make([]bool, width*cellW*height*cellH),
+		colors: make([]color.Color, width*height),
+	}
+}
+
+// Width returns the width of the grid in pixels.
+func (g *PixelGrid) Width() int {
+	return g.width * g.cellW
+}
+
+// Height returns the height of the grid in pixels.
+func (g *PixelGrid) Height() int {
+	return g.height * g.cellH
+}
+
+// Bounds returns the bounds of the grid in cells.
+func (g *PixelGrid) Bounds() uv.Rectangle {
+	return uv.Rect(0, 0, g.width, g.height)
+}
+
+// Set turns on the pixel at (x, y) and sets the color of its cell. A nil
+// color uses the terminal's default foreground color. Pixels outside of the
+// grid are ignored.
+func (g *PixelGrid) Set(x, y int, c color.Color) *PixelGrid {
+	if !g.inBounds(x, y) {
+		return g
+	}
+	g.pixels[y*g.Width()+x] = true
+	g.colors[(y/g.cellH)*g.width+x/g.cellW] = c
+	return g
+}
+
+// Unset turns off the pixel at (x, y).
+func (g *PixelGrid) Unset(x, y int) *PixelGrid {
+	if g.inBounds(x, y) {
+		g.pixels[y*g.Width()+x] = false
+	}
+	return g
+}
+
+// Get returns whether the pixel at (x, y) is on.
+func (g *PixelGrid) Get(x, y int) bool {
+	return g.inBounds(x, y) && g.pixels[y*g.Width()+x]
+}
+
+// Clear turns off all pixels.
+func (g *PixelGrid) Clear() *PixelGrid {
+	clear(g.pixels)
+	clear(g.colors)
+	return g
+}
+
+// inBounds returns whether (x, y) is inside of the grid.
+func (g *PixelGrid) inBounds(x, y int) bool {
+	return x >= 0 && y >= 0 && x < g.Width() && y < g.Height()
+}
+
+// Line draws a line from (x0, y0) to (x1, y1), both included, using
+// Bresenham's algorithm.
+func (g *PixelGrid) Line(x0, y0, x1, y1 int, c color.Color) *PixelGrid {
+	dx, dy := abs(x1-x0), -abs(y1-y0)
+	sx, sy := 1, 1
+	if x0 > x1 {
+		sx = -1
+	}
+	if y0 > y1 {
+		sy = -1
+	}
+	err := dx + dy
+	for {
+		g.Set(x0, y0, c)
+		if x0 == x1 && y0 == y1 {
+			return g
+		}
+		e2 := 2 * err //nolint:mnd
+		if e2 >= dy {
+			err += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
+
+// Circle draws the outline of a circle centered at (cx, cy) with the given
+// radius, using the midpoint circle algorithm.
+func (g *PixelGrid) Circle(cx, cy, radius int, c color.Color) *PixelGrid {
+	g.circle(cx, cy, radius, func(x0, x1, y int) {
+		g.Set(x0, y, c)
+		g.Set(x1, y, c)
+	})
+	return g
+}
+
+// FillCircle draws a filled circle centered at (cx, cy) with the given
+// radius.
+func (g *PixelGrid) FillCircle(cx, cy, radius int, c color.Color) *PixelGrid {
+	g.circle(cx, cy, radius, func(x0, x1, y int) {
+		for x := x0; x <= x1; x++ {
+			g.Set(x, y, c)
+		}
+	})
+	return g
+}
+
+// circle calls span with the horizontal spans of the outline of a circle.
+func (g *PixelGrid) circle(cx, cy, radius int, span func(x0, x1, y int)) {
+	if radius < 0 {
+		return
+	}
+	x, y := radius, 0
+	err := 1 - radius
+	for x >= y {
+		span(cx-x, cx+x, cy+y)
+		span(cx-x, cx+x, cy-y)
+		span(cx-y, cx+y, cy+x)
+		span(cx-y, cx+y, cy-x)
+		y++
+		if err < 0 {
+			err += 2*y + 1 //nolint:mnd
+		} else {
+			x--
+			err += 2*(y-x) + 1 //nolint:mnd
+		}
+	}
+}
+
+// Draw draws the grid onto the screen at the given area. Cells without any
+// pixels set are skipped.
+//
+// It implements [uv.Drawable].
+func (g *PixelGrid) Draw(scr uv.Screen, area uv.Rectangle) {
+	for cy := range g.height {
+		for cx := range g.width {
+			x, y := area.Min.X+cx, area.Min.Y+cy
+			if x >= area.Max.X || y >= area.Max.Y {
+				continue
+			}
+			glyph := g.glyph(cx, cy)
+			if glyph == 0 {
+				continue
+			}
+			cell := uv.NewCell(scr.WidthMethod(), string(glyph))
+			cell.Style.Fg = g.colors[cy*g.width+cx]
+			scr.SetCell(x, y, cell)
+		}
+	}
+}
+
+// glyph returns the character for the cell at (cx, cy), or 0 if no pixels
+// are set in it.
+func (g *PixelGrid) glyph(cx, cy int) rune {
+	// Collect the pixels of the cell as bits, left to right, top to bottom.
+	var bits int
+	for py := range g.cellH {
+		for px := range g.cellW {
+			if g.pixels[(cy*g.cellH+py)*g.Width()+cx*g.cellW+px] {
+				bits |= 1 << (py*g.cellW + px)
+			}
+		}
+	}
+	if bits == 0 {
+		return 0
+	}
+
+	switch g.mode {
+	case QuadrantPixels:
+		return quadrants[bits]
+	case HalfBlockPixels:
+		return halfBlocks[bits]
+	case SextantPixels:
+		return sextant(bits)
+	default:
+		return braille(bits)
+	}
+}
+
+// quadrants maps 2x2 pixel bits to quadrant block elements.
+var quadrants = [16]rune{
+	' ', '▘', '▝', '▀', '▖', '▌', '▞', '▛',
+	'▗', '▚', '▐', '▜', '▄', '▙', '▟', '█',
+}
+
+// halfBlocks maps 1x2 pixel bits to half block elements.
+var halfBlocks = [4]rune{' ', '▀', '▄', '█'}
+
+// braille maps 2x4 pixel bits to a braille pattern. Braille dots are
+// numbered top to bottom in the first column, then in the second column, with
+// the bottom row added last.
+func braille(bits int) rune {
+	dots := [8]int{0x01, 0x08, 0x02, 0x10, 0x04, 0x20, 0x40, 0x80}
+	r := rune(0x2800) //nolint:mnd
+	for i, dot := range dots {
+		if bits&(1<<i) != 0 {
+			r += rune(dot)
+		}
+	}
+	return r
+}
+
+// sextant maps 2x3 pixel bits to a sextant block element. Sextants are
+// encoded in order, skipping the patterns that already exist as block
+// elements.
+func sextant(bits int) rune {
+	switch bits {
+	case 0:
+		return ' '
+	case 0b010101:
+		return '▌'
+	case 0b101010:
+		return '▐'
+	case 0b111111:
+		return '█'
+	}
+	i := bits - 1
+	if bits > 0b010101 {
+		i--
+	}
+	if bits > 0b101010 {
+		i--
+	}
+	return rune(0x1FB00 + i) //nolint:mnd
+}
@@ -0,0 +1,120 @@
+package lipgloss
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestPixelGridGlyphs(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     PixelMode
+		pixels   [][2]int
+		expected string
+	}{
+		{"braille dot 1", BraillePixels, [][2]int{{0, 0}}, "⠁"},
+		{"braille dot 4", BraillePixels, [][2]int{{1, 0}}, "⠈"},
+		{"braille dot 7", BraillePixels, [][2]int{{0, 3}}, "⡀"},
+		{"braille dot 8", BraillePixels, [][2]int{{1, 3}}, "⢀"},
+		{"braille left column", BraillePixels, [][2]int{{0, 0}, {0, 1}, {0, 2}, {0, 3}}, "⡇"},
+		{"quadrant top left", QuadrantPixels, [][2]int{{0, 0}}, "▘"},
+		{"quadrant diagonal", QuadrantPixels, [][2]int{{1, 0}, {0, 1}}, "▞"},
+		{"half block top", HalfBlockPixels, [][2]int{{0, 0}}, "▀"},
+		{"half block bottom", HalfBlockPixels, [][2]int{{0, 1}}, "▄"},
+		{"half block full", HalfBlockPixels, [][2]int{{0, 0}, {0, 1}}, "█"},
+		{"sextant 1", SextantPixels, [][2]int{{0, 0}}, "\U0001FB00"},
+		{"sextant 2", SextantPixels, [][2]int{{1, 0}}, "\U0001FB01"},
+		{"sextant left column", SextantPixels, [][2]int{{0, 0}, {0, 1}, {0, 2}}, "▌"},
+		{"sextant 1346", SextantPixels, [][2]int{{0, 0}, {0, 1}, {1, 1}, {1, 2}}, "\U0001FB2A"},
+		{"sextant 23456", SextantPixels, [][2]int{{1, 0}, {0, 1}, {1, 1}, {0, 2}, {1, 2}}, "\U0001FB3B"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewPixelGrid(1, 1, tc.mode)
+			for _, p := range tc.pixels {
+				g.Set(p[0], p[1], nil)
+			}
+			c := NewCanvas(1, 1)
+			c.Compose(g)
+			if got := c.Render(); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestPixelGridLine(t *testing.T) {
+	g := NewPixelGrid(4, 2, QuadrantPixels)
+	g.Line(0, 0, 7, 3, nil)
+
+	expected := strings.Join([]string{
+		"▀▄",
+		"  ▀▄",
+	}, "\n")
+	c := NewCanvas(4, 2)
+	c.Compose(g)
+	if got := c.Render(); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+
+	// Lines are symmetric.
+	r := NewPixelGrid(4, 2, QuadrantPixels)
+	r.Line(7, 3, 0, 0, nil)
+	for y := range g.Height() {
+		for x := range g.Width() {
+			if g.Get(x, y) != r.Get(x, y) {
+				t.Errorf("pixel (%d, %d) differs when drawn backwards", x, y)
+			}
+		}
+	}
+}
+
+func TestPixelGridCircle(t *testing.T) {
+	g := NewPixelGrid(5, 5, HalfBlockPixels)
+	g.Circle(2, 4, 2, nil)
+
+	for _, p := range [][2]int{{0, 4}, {4, 4}, {2, 2}, {2, 6}} {
+		if !g.Get(p[0], p[1]) {
+			t.Errorf("expected pixel (%d, %d) to be set", p[0], p[1])
+		}
+	}
+	if g.Get(2, 4) {
+		t.Error("expected the center of the circle not to be set")
+	}
+
+	g.Clear().FillCircle(2, 4, 2, nil)
+	if !g.Get(2, 4) {
+		t.Error("expected the center of the filled circle to be set")
+	}
+}
+
+func TestPixelGridColors(t *testing.T) {
+	g := NewPixelGrid(2, 1, BraillePixels)
+	g.Set(0, 0, color.RGBA{R: 0xff, A: 0xff})
+	g.Set(1, 1, color.RGBA{B: 0xff, A: 0xff})
+
+	c := NewCanvas(3, 1)
+	c.Text(0, 0, "abc", NewStyle())
+	c.Compose(g)
+
+	// Cells without pixels let the content underneath show through.
+	if got := c.CellAt(1, 0).Content; got != "b" {
+		t.Errorf("expected empty cell to be transparent, got %q", got)
+	}
+
+	// The last pixel set in a cell determines its color.
+	cell := c.CellAt(0, 0)
+	if cell.Content != "⠑" {
+		t.Errorf("expected ⠑, got %q", cell.Content)
+	}
+	if got := toRGBA(cell.Style.Fg); got != (color.RGBA{B: 0xff, A: 0xff}) {
+		t.Errorf("expected blue foreground, got %v", got)
+	}
+
+	// Out of bounds pixels are ignored.
+	g.Set(-1, 0, nil).Set(4, 0, nil).Set(0, 4, nil)
+	if g.Get(-1, 0) || g.Get(4, 0) {
+		t.Error("expected out of bounds pixels to be ignored")
+	}
+}
@@ -28,6 +28,8 @@ func (s *Style) set(key propKey, value any) {
 		s.alignHorizontal = value.(Position)
 	case alignVerticalKey:
 		s.alignVertical = value.(Position)
+	case alignDecimalKey:
+		s.alignDecimal = value.(rune)
 	case paddingTopKey:
 		s.paddingTop = max(0, value.(int))
 	case paddingRightKey:
@@ -126,6 +128,8 @@ func (s *Style) setFrom(key propKey, i Style) {
 		s.set(alignHorizontalKey, i.alignHorizontal)
 	case alignVerticalKey:
 		s.set(alignVerticalKey, i.alignVertical)
+	case alignDecimalKey:
+		s.set(alignDecimalKey, i.alignDecimal)
 	case paddingTopKey:
 		s.set(paddingTopKey, i.paddingTop)
 	case paddingRightKey:
@@ -324,6 +328,19 @@ func (s Style) AlignVertical(p Position) Style {
 	return s
 }
 
+// AlignDecimal sets a rule to line the numbers of the lines of a block up on
+// the given decimal separator, like '.' or ','. See [AlignDecimal] for
+// details. The block as a whole is then aligned horizontally.
+//
+// Example:
+//
+//	s := lipgloss.NewStyle().AlignDecimal('.').AlignHorizontal(lipgloss.Right)
+//	fmt.Println(s.Render("1,024.5 MB\n12 KB\n3.25 GB"))
+func (s Style) AlignDecimal(sep rune) Style {
+	s.set(alignDecimalKey, sep)
+	return s
+}
+
 // Padding is a shorthand method for setting padding on all sides at once.
 //
 // With one argument, the value is applied to all sides.
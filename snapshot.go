@@ -0,0 +1,270 @@
+package lipgloss
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/aymanbagabas/go-udiff"
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Snapshot is a structured snapshot of the cells of a [Canvas]: a grid of
+// graphemes, each with the ID of its resolved style and hyperlink. It's meant
+// for tests, where comparing rendered ANSI strings is brittle and hard to
+// review.
+//
+// A Snapshot can be serialized to JSON or dumped to a readable text format
+// with [Snapshot.String], which can be compared with [Snapshot.Diff] or
+// stored in golden files:
+//
+//	╭──╮
+//	│hi│
+//	╰──╯
+//	--- styles
+//	aaaa
+//	a..a
+//	aaaa
+//	--- legend
+//	a: fg=#ff5f87 bold
+type Snapshot struct {
+	// Width and Height are the size of the canvas.
+	Width  int `json:"width"`
+	Height int `json:"height"`
+
+	// Cells are the cells of the canvas, row by row. The cells covered by a
+	// wide grapheme have empty contents and the same style as the grapheme.
+	Cells [][]SnapshotCell `json:"cells"`
+
+	// Styles are the styles used in the canvas, by ID. Cells with the default
+	// style and no hyperlink have the ID [SnapshotDefaultStyle], which is not
+	// in the map.
+	Styles map[string]SnapshotStyle `json:"styles"`
+}
+
+// SnapshotCell is a cell of a [Snapshot].
+type SnapshotCell struct {
+	// Content is the grapheme in the cell.
+	Content string `json:"content"`
+
+	// Style is the ID of the style of the cell in [Snapshot.Styles].
+	Style string `json:"style"`
+}
+
+// SnapshotStyle is the resolved style and hyperlink of a [SnapshotCell].
+// Colors are formatted as "#rrggbb", "ansi:n" for the 16 basic colors and
+// "ansi256:n" for indexed colors.
+type SnapshotStyle struct {
+	Foreground     string   `json:"fg,omitempty"`
+	Background     string   `json:"bg,omitempty"`
+	Underline      string   `json:"underline,omitempty"`
+	UnderlineColor string   `json:"underlineColor,omitempty"`
+	Attrs          []string `json:"attrs,omitempty"`
+	Link           string   `json:"link,omitempty"`
+	LinkParams     string   `json:"linkParams,omitempty"`
+}
+
+// SnapshotDefaultStyle is the style ID of cells with the default style and no
+// hyperlink.
+const SnapshotDefaultStyle = "."
+
+// snapshotStyleIDs are the characters used as style IDs, in order. When
+// they run out, IDs continue with two characters, like "aa", and so on.
+const snapshotStyleIDs = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// Snapshot returns a [Snapshot] of the cells of the canvas. Style IDs are
+// assigned in the order the styles first appear, row by row.
+func (c *Canvas) Snapshot() *Snapshot {
+	s := &Snapshot{
+		Width:  c.Width(),
+		Height: c.Height(),
+		Cells:  make([][]SnapshotCell, c.Height()),
+		Styles: map[string]SnapshotStyle{},
+	}
+
+	ids := map[string]string{}
+	for y := range c.Height() {
+		row := make([]SnapshotCell, c.Width())
+		var wide SnapshotCell
+		for x := range c.Width() {
+			cell := c.CellAt(x, y)
+			if cell == nil || cell.IsZero() {
+				// Covered by a wide grapheme.
+				row[x] = SnapshotCell{Style: wide.Style}
+				continue
+			}
+
+			style := snapshotStyle(cell)
+			key := style.String()
+			id := SnapshotDefaultStyle
+			if key != "" {
+				var ok bool
+				if id, ok = ids[key]; !ok {
+					id = snapshotStyleID(len(ids))
+					ids[key] = id
+					s.Styles[id] = style
+				}
+			}
+			row[x] = SnapshotCell{Content: cell.Content, Style: id}
+			wide = row[x]
+		}
+		s.Cells[y] = row
+	}
+	return s
+}
+
+// snapshotStyleID returns the style ID with the given index.
+func snapshotStyleID(i int) string {
+	// Skip the IDs with fewer characters.
+	n := len(snapshotStyleIDs)
+	width, count := 1, n
+	for i >= count {
+		i -= count
+		width++
+		count *= n
+	}
+
+	id := make([]byte, width)
+	for k := width - 1; k >= 0; k-- {
+		id[k] = snapshotStyleIDs[i%n]
+		i /= n
+	}
+	return string(id)
+}
+
+// snapshotStyle resolves the style and hyperlink of a cell.
+func snapshotStyle(cell *uv.Cell) SnapshotStyle {
+	st := cell.Style
+	s := SnapshotStyle{
+		Foreground:     snapshotColor(st.Fg),
+		Background:     snapshotColor(st.Bg),
+		UnderlineColor: snapshotColor(st.UnderlineColor),
+		Link:           cell.Link.URL,
+		LinkParams:     cell.Link.Params,
+	}
+	switch st.Underline {
+	case uv.UnderlineSingle:
+		s.Underline = "single"
+	case uv.UnderlineDouble:
+		s.Underline = "double"
+	case uv.UnderlineCurly:
+		s.Underline = "curly"
+	case uv.UnderlineDotted:
+		s.Underline = "dotted"
+	case uv.UnderlineDashed:
+		s.Underline = "dashed"
+	}
+	for _, attr := range []struct {
+		attr uint8
+		name string
+	}{
+		{uv.AttrBold, "bold"},
+		{uv.AttrFaint, "faint"},
+		{uv.AttrItalic, "italic"},
+		{uv.AttrBlink, "blink"},
+		{uv.AttrRapidBlink, "rapidblink"},
+		{uv.AttrReverse, "reverse"},
+		{uv.AttrConceal, "conceal"},
+		{uv.AttrStrikethrough, "strikethrough"},
+	} {
+		if st.Attrs&attr.attr != 0 {
+			s.Attrs = append(s.Attrs, attr.name)
+		}
+	}
+	return s
+}
+
+// snapshotColor formats a color for a [SnapshotStyle].
+func snapshotColor(c color.Color) string {
+	switch c := c.(type) {
+	case nil:
+		return ""
+	case ansi.BasicColor:
+		return fmt.Sprintf("ansi:%d", c)
+	case ansi.IndexedColor:
+		return fmt.Sprintf("ansi256:%d", c)
+	}
+	r, g, b, a := c.RGBA()
+	if a != 0xffff {
+		return fmt.Sprintf("#%02x%02x%02x%02x", r>>8, g>>8, b>>8, a>>8)
+	}
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
+
+// String returns the style as it appears in the legend of
+// [Snapshot.String]. The default style is an empty string.
+func (s SnapshotStyle) String() string {
+	var parts []string
+	add := func(name, value string) {
+		if value != "" {
+			parts = append(parts, name+"="+value)
+		}
+	}
+	add("fg", s.Foreground)
+	add("bg", s.Background)
+	add("underline", s.Underline)
+	add("ulcolor", s.UnderlineColor)
+	parts = append(parts, s.Attrs...)
+	add("link", s.Link)
+	add("params", s.LinkParams)
+	return strings.Join(parts, " ")
+}
+
+// String returns a readable dump of the snapshot: the graphemes of the
+// canvas, followed by the style ID of every cell and a legend of the styles.
+// Rows are not trimmed, so columns line up between the sections. With more
+// than 62 styles, IDs have several characters, and every cell is padded to
+// the width of the longest ID.
+func (s *Snapshot) String() string {
+	width := 1
+	for _, row := range s.Cells {
+		for _, cell := range row {
+			width = max(width, len(cell.Style))
+		}
+	}
+
+	var b strings.Builder
+	for _, row := range s.Cells {
+		for _, cell := range row {
+			// Cells covered by wide graphemes are empty.
+			b.WriteString(cell.Content)
+			if width > 1 {
+				b.WriteString(strings.Repeat(" ", max(width-ansi.StringWidth(cell.Content), 0)))
+			}
+		}
+		b.WriteByte('\n')
+	}
+
+	b.WriteString("--- styles\n")
+	for _, row := range s.Cells {
+		for _, cell := range row {
+			id := cell.Style
+			if id == "" {
+				id = SnapshotDefaultStyle
+			}
+			b.WriteString(id + strings.Repeat(" ", width-len(id)))
+		}
+		b.WriteByte('\n')
+	}
+
+	b.WriteString("--- legend\n")
+	for i := range len(s.Styles) {
+		id := snapshotStyleID(i)
+		if style, ok := s.Styles[id]; ok {
+			b.WriteString(id + ": " + style.String() + "\n")
+		}
+	}
+	return b.String()
+}
+
+// Diff returns a unified diff between the text dumps of the snapshot and
+// another one, or an empty string if they are the same. It's meant for test
+// failures:
+//
+//	if diff := expected.Diff(canvas.Snapshot()); diff != "" {
+//		t.Errorf("unexpected canvas:\n%s", diff)
+//	}
+func (s *Snapshot) Diff(other *Snapshot) string {
+	return udiff.Unified("expected", "got", s.String(), other.String())
+}
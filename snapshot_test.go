@@ -0,0 +1,118 @@
+package lipgloss
+
+import (
+	"encoding/json"
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestCanvasSnapshot(t *testing.T) {
+	c := NewCanvas(5, 3)
+	c.Rect(image.Rect(0, 0, 4, 3), RoundedBorder(), NewStyle().Foreground(Color("#ff5f87")).Bold(true))
+	c.Text(1, 1, "hi", NewStyle().Hyperlink("https://charm.land"))
+	c.Text(4, 2, "x", NewStyle().Background(ansi.IndexedColor(212)).Underline(true))
+
+	expected := strings.Join([]string{
+		"╭──╮ ",
+		"│hi│ ",
+		"╰──╯x",
+		"--- styles",
+		"aaaa.",
+		"abba.",
+		"aaaac",
+		"--- legend",
+		"a: fg=#ff5f87 bold",
+		"b: link=https://charm.land",
+		"c: bg=ansi256:212 underline=single",
+		"",
+	}, "\n")
+	if got := c.Snapshot().String(); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestCanvasSnapshotWide(t *testing.T) {
+	c := NewCanvas(4, 1)
+	c.Text(0, 0, "日本", NewStyle().Foreground(ansi.Red))
+
+	s := c.Snapshot()
+	if got := s.Cells[0][1]; got.Content != "" || got.Style != "a" {
+		t.Errorf("expected the cell covered by a wide grapheme to be empty, got %+v", got)
+	}
+	expected := "日本\n--- styles\naaaa\n--- legend\na: fg=ansi:1\n"
+	if got := s.String(); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestCanvasSnapshotManyStyles(t *testing.T) {
+	c := NewCanvas(70, 1)
+	for x := range 70 {
+		c.Text(x, 0, "x", NewStyle().Foreground(ansi.IndexedColor(x+16)))
+	}
+
+	// IDs continue with two characters once the single ones run out.
+	s := c.Snapshot()
+	if len(s.Styles) != 70 {
+		t.Fatalf("expected 70 styles, got %d", len(s.Styles))
+	}
+	for x, expected := range map[int]string{0: "a", 61: "9", 62: "aa", 69: "ah"} {
+		if got := s.Cells[0][x].Style; got != expected {
+			t.Errorf("expected the style ID of cell %d to be %q, got %q", x, expected, got)
+		}
+	}
+
+	// Cells are padded to two characters so the sections line up.
+	lines := strings.Split(s.String(), "\n")
+	if got := lines[0]; got != strings.Repeat("x ", 70) {
+		t.Errorf("expected padded graphemes, got %q", got)
+	}
+	if got := lines[2]; !strings.HasPrefix(got, "a b ") || !strings.HasSuffix(got, "9 aaabacadaeafagah") || len(got) != 140 {
+		t.Errorf("expected padded style IDs, got %q", got)
+	}
+	if got := lines[3+1+62]; got != "aa: fg=ansi256:78" {
+		t.Errorf("expected the legend of the 63rd style, got %q", got)
+	}
+}
+
+func TestCanvasSnapshotJSON(t *testing.T) {
+	c := NewCanvas(2, 1)
+	c.Text(0, 0, "a", NewStyle().Italic(true))
+
+	b, err := json.Marshal(c.Snapshot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"width":2,"height":1,"cells":[[{"content":"a","style":"a"},{"content":" ","style":"."}]],"styles":{"a":{"attrs":["italic"]}}}`
+	if string(b) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, b)
+	}
+
+	var s Snapshot
+	if err := json.Unmarshal(b, &s); err != nil {
+		t.Fatal(err)
+	}
+	if diff := c.Snapshot().Diff(&s); diff != "" {
+		t.Errorf("expected the snapshot to survive a round trip:\n%s", diff)
+	}
+}
+
+func TestSnapshotDiff(t *testing.T) {
+	a := NewCanvas(3, 1)
+	a.Text(0, 0, "abc", NewStyle())
+	b := NewCanvas(3, 1)
+	b.Text(0, 0, "abc", NewStyle().Bold(true))
+
+	if diff := a.Snapshot().Diff(a.Snapshot()); diff != "" {
+		t.Errorf("expected no diff, got:\n%s", diff)
+	}
+	diff := a.Snapshot().Diff(b.Snapshot())
+	for _, line := range []string{"-...", "+aaa", "+a: bold"} {
+		if !strings.Contains(diff, line+"\n") {
+			t.Errorf("expected the diff to contain %q, got:\n%s", line, diff)
+		}
+	}
+}
@@ -39,6 +39,7 @@ const (
 	heightKey
 	alignHorizontalKey
 	alignVerticalKey
+	alignDecimalKey
 
 	// Padding.
 	paddingTopKey
@@ -161,6 +162,7 @@ type Style struct {
 
 	alignHorizontal Position
 	alignVertical   Position
+	alignDecimal    rune
 
 	paddingTop    int
 	paddingRight  int
@@ -414,6 +416,11 @@ func (s Style) Render(strs ...string) string {
 		str = Wrap(str, wrapAt, "")
 	}
 
+	// Line numbers up on their decimal separator
+	if sep := s.getAsRune(alignDecimalKey); sep != 0 && !inline {
+		str = strings.Join(AlignDecimal(sep, strings.Split(str, "\n")...), "\n")
+	}
+
 	// Render core text
 	{
 		var b strings.Builder
@@ -0,0 +1,85 @@
+package table
+
+import (
+	"fmt"
+
+	"charm.land/lipgloss/v2"
+)
+
+// Cell is a cell of [RichData], with a typed value and its own style.
+type Cell struct {
+	// Value is the typed value of the cell, like a number or a time. Cells
+	// with numeric values are aligned to the right, unless their column or
+	// style is aligned.
+	Value any
+
+	// Display is the text shown in the cell. When empty, the value is shown
+	// instead.
+	Display string
+
+	// Style is layered over the style of the cell from [StyleFunc], except
+	// for its padding and margins, so that the cell keeps its size. Use it
+	// to align the cell, too.
+	Style lipgloss.Style
+
+	// Link is the URL of a hyperlink over the text of the cell. It's kept
+	// when the text is truncated.
+	Link string
+
+	// SortKey is compared by [Sort] instead of the text of the cell, when
+	// set.
+	SortKey string
+}
+
+// String returns the text shown in the cell.
+func (c Cell) String() string {
+	if c.Display != "" || c.Value == nil {
+		return c.Display
+	}
+	return fmt.Sprint(c.Value)
+}
+
+// numeric returns whether the value of the cell is a number.
+func (c Cell) numeric() bool {
+	switch c.Value.(type) {
+	case int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		float32, float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// style returns the style of the cell, with its hyperlink.
+func (c Cell) style() lipgloss.Style {
+	if c.Link == "" {
+		return c.Style
+	}
+	return c.Style.Hyperlink(c.Link)
+}
+
+// RichData is implemented by [Data] whose cells have typed values, styles and
+// hyperlinks. Its At method should return the text shown in the cells.
+type RichData interface {
+	// Cell returns the cell at the given index, and whether it's a rich
+	// cell rather than just text.
+	Cell(row, cell int) (c Cell, ok bool)
+}
+
+// sortText returns the text of a cell compared when sorting.
+func sortText(data Data, row, col int) string {
+	if c, ok := richCell(data, row, col); ok && c.SortKey != "" {
+		return c.SortKey
+	}
+	return data.At(row, col)
+}
+
+// richCell returns the rich cell at the given index of some data, if the data
+// implements [RichData].
+func richCell(data Data, row, col int) (Cell, bool) {
+	if rich, ok := data.(RichData); ok {
+		return rich.Cell(row, col)
+	}
+	return Cell{}, false
+}
@@ -0,0 +1,82 @@
+package table
+
+import (
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/exp/golden"
+)
+
+func TestRichCells(t *testing.T) {
+	data := NewStringData(
+		[]string{"lipgloss", "", ""},
+		[]string{"bubbletea", "", ""},
+		[]string{"glow", "", ""},
+	).
+		SetCell(0, 1, Cell{Value: 9001}).
+		SetCell(1, 1, Cell{Value: 31.5}).
+		SetCell(2, 1, Cell{Value: 17, Style: lipgloss.NewStyle().Foreground(lipgloss.Color("9"))}).
+		SetCell(0, 2, Cell{Display: "https://github.com/charmbracelet/lipgloss", Link: "https://github.com/charmbracelet/lipgloss"}).
+		SetCell(1, 2, Cell{Display: "n/a", Style: lipgloss.NewStyle().AlignHorizontal(lipgloss.Center)})
+
+	table := New().
+		Headers("Name", "Stars", "Repository").
+		Data(data).
+		Width(40).
+		Wrap(false)
+
+	out := table.String()
+	if !strings.Contains(out, ansi.SetHyperlink("https://github.com/charmbracelet/lipgloss")) {
+		t.Fatalf("expected the link to survive truncation:\n%s", out)
+	}
+	golden.RequireEqual(t, []byte(out))
+}
+
+func TestRichCellsSort(t *testing.T) {
+	data := NewStringData(
+		[]string{"Mar 3"},
+		[]string{"Jan 12"},
+		[]string{"Feb 7"},
+	).
+		SetCell(0, 0, Cell{Display: "Mar 3", SortKey: "03-03"}).
+		SetCell(1, 0, Cell{Display: "Jan 12", SortKey: "01-12"}).
+		SetCell(2, 0, Cell{Display: "Feb 7", SortKey: "02-07"})
+
+	sorted := NewSort(data).By(0, Ascending, nil)
+
+	var got []string
+	for i := range sorted.Rows() {
+		got = append(got, sorted.At(i, 0))
+	}
+	if want := []string{"Jan 12", "Feb 7", "Mar 3"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if c, ok := sorted.Cell(0, 0); !ok || c.SortKey != "01-12" {
+		t.Fatalf("expected the first cell to be sorted by its key, got %+v", c)
+	}
+}
+
+func TestRichCellsGrowRow(t *testing.T) {
+	row := []string{"lipgloss"}
+	data := NewStringData(row).
+		SetCell(0, 2, Cell{Value: 9001}).
+		SetCell(1, 0, Cell{Value: "missing"})
+
+	if got := data.At(0, 2); got != "9001" {
+		t.Errorf("expected the cell past the row to be set, got %q", got)
+	}
+	if got := data.At(0, 1); got != "" {
+		t.Errorf("expected an empty cell, got %q", got)
+	}
+	if got := data.Columns(); got != 3 {
+		t.Errorf("expected 3 columns, got %d", got)
+	}
+	if got := data.Rows(); got != 1 {
+		t.Errorf("expected the missing row to be ignored, got %d rows", got)
+	}
+	if len(row) != 1 {
+		t.Errorf("expected the original row to be left alone, got %v", row)
+	}
+}
@@ -0,0 +1,142 @@
+package table
+
+import "charm.land/lipgloss/v2"
+
+// Column configures the layout of a table column: its header, alignment,
+// width constraints, how its content overflows and how important it is when
+// the table is too narrow.
+//
+// Example:
+//
+//	t := table.New().
+//	    Width(40).
+//	    Columns(
+//	        table.NewColumn("ID").Align(lipgloss.Right).Width(6),
+//	        table.NewColumn("Name").MinWidth(10),
+//	        table.NewColumn("Description").Wrap(false).Priority(1),
+//	    )
+type Column struct {
+	header   string
+	align    lipgloss.Position
+	alignSet bool
+	width    int
+	minWidth int
+	maxWidth int
+	wrap     bool
+	wrapSet  bool
+	tail     string
+	priority int
+	hideable bool
+	decimal  rune
+}
+
+// NewColumn returns a new column with the given header.
+func NewColumn(header string) *Column {
+	return &Column{header: header, tail: "…"}
+}
+
+// Align sets the horizontal alignment of the column's cells, including its
+// header. Alignments set through [StyleFunc] take precedence.
+func (c *Column) Align(p lipgloss.Position) *Column {
+	c.align = p
+	c.alignSet = true
+	return c
+}
+
+// AlignDecimal lines the numbers of the column's cells and footer up on the
+// given decimal separator, like '.' or ','. Thousands separators and unit
+// suffixes line up too, see [lipgloss.AlignDecimal]. The aligned numbers are
+// aligned to the right of the column, unless the column is aligned with
+// [Column.Align].
+func (c *Column) AlignDecimal(sep rune) *Column {
+	c.decimal = sep
+	return c
+}
+
+// Width sets a fixed width for the column, including the cell padding. The
+// resizer never grows or shrinks a column with a fixed width.
+func (c *Column) Width(w int) *Column {
+	c.width = max(w, 0)
+	return c
+}
+
+// MinWidth sets the minimum width of the column, including the cell padding.
+// The resizer doesn't shrink the column below this width.
+func (c *Column) MinWidth(w int) *Column {
+	c.minWidth = max(w, 0)
+	return c
+}
+
+// MaxWidth sets the maximum width of the column, including the cell padding.
+// The resizer doesn't grow the column beyond this width, and content that
+// doesn't fit is wrapped or truncated.
+func (c *Column) MaxWidth(w int) *Column {
+	c.maxWidth = max(w, 0)
+	return c
+}
+
+// Wrap sets whether the content of the column wraps when it doesn't fit.
+// When false, the content is truncated with the column's tail instead. By
+// default, columns follow [Table.Wrap].
+func (c *Column) Wrap(w bool) *Column {
+	c.wrap = w
+	c.wrapSet = true
+	return c
+}
+
+// Tail sets the string appended to truncated content. The default is "…".
+func (c *Column) Tail(tail string) *Column {
+	c.tail = tail
+	return c
+}
+
+// Priority sets the priority of the column and makes it hideable. When the
+// table is too narrow to fit all columns at their minimum width, hideable
+// columns are hidden, lowest priority first. Columns without a priority are
+// never hidden.
+func (c *Column) Priority(p int) *Column {
+	c.priority = p
+	c.hideable = true
+	return c
+}
+
+// GetHeader returns the header of the column.
+func (c *Column) GetHeader() string {
+	return c.header
+}
+
+// GetAlign returns the horizontal alignment of the column.
+func (c *Column) GetAlign() lipgloss.Position {
+	return c.align
+}
+
+// GetAlignDecimal returns the decimal separator the numbers of the column are
+// lined up on, or 0 if not set.
+func (c *Column) GetAlignDecimal() rune {
+	return c.decimal
+}
+
+// GetWidth returns the fixed width of the column, or 0 if not set.
+func (c *Column) GetWidth() int {
+	return c.width
+}
+
+// GetMinWidth returns the minimum width of the column, or 0 if not set.
+func (c *Column) GetMinWidth() int {
+	return c.minWidth
+}
+
+// GetMaxWidth returns the maximum width of the column, or 0 if not set.
+func (c *Column) GetMaxWidth() int {
+	return c.maxWidth
+}
+
+// GetTail returns the string appended to truncated content.
+func (c *Column) GetTail() string {
+	return c.tail
+}
+
+// GetPriority returns the priority of the column.
+func (c *Column) GetPriority() int {
+	return c.priority
+}
@@ -0,0 +1,146 @@
+package table
+
+import (
+	"slices"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/exp/golden"
+)
+
+var columnRows = [][]string{
+	{"1", "Kini", "A small dog who likes to sleep in the sun"},
+	{"22", "Eli", "Enjoys long walks"},
+	{"333", "Iris", "Has been around for more than a hundred years"},
+}
+
+func TestColumns(t *testing.T) {
+	table := New().
+		StyleFunc(TableStyle).
+		Columns(
+			NewColumn("ID").Align(lipgloss.Right).Width(6),
+			NewColumn("Name"),
+			NewColumn("Description"),
+		).
+		Rows(columnRows...)
+
+	if headers := table.GetHeaders(); !slices.Equal(headers, []string{"ID", "Name", "Description"}) {
+		t.Fatalf("expected the headers of the columns, got %v", headers)
+	}
+	golden.RequireEqual(t, []byte(table.String()))
+}
+
+func TestColumnsMinMaxWidth(t *testing.T) {
+	t.Run("Shrink", func(t *testing.T) {
+		table := New().
+			Width(36).
+			StyleFunc(TableStyle).
+			Columns(
+				NewColumn("ID"),
+				NewColumn("Name").MinWidth(10),
+				NewColumn("Description"),
+			).
+			Rows(columnRows...)
+		golden.RequireEqual(t, []byte(table.String()))
+	})
+
+	t.Run("Expand", func(t *testing.T) {
+		table := New().
+			Width(80).
+			StyleFunc(TableStyle).
+			Columns(
+				NewColumn("ID").MaxWidth(5),
+				NewColumn("Name").MaxWidth(8),
+				NewColumn("Description"),
+			).
+			Rows(columnRows...)
+		golden.RequireEqual(t, []byte(table.String()))
+	})
+
+	t.Run("MaxWidthOnly", func(t *testing.T) {
+		table := New().
+			Width(80).
+			StyleFunc(TableStyle).
+			Columns(
+				NewColumn("ID").MaxWidth(5),
+				NewColumn("Name").MaxWidth(8),
+				NewColumn("Description").MaxWidth(20),
+			).
+			Rows(columnRows...)
+		golden.RequireEqual(t, []byte(table.String()))
+	})
+}
+
+func TestColumnsTruncate(t *testing.T) {
+	table := New().
+		Width(40).
+		StyleFunc(TableStyle).
+		Columns(
+			NewColumn("ID"),
+			NewColumn("Name").MaxWidth(6).Wrap(false).Tail("."),
+			NewColumn("Description").Wrap(false),
+		).
+		Rows(columnRows...).
+		Row("4", "Nathaniel", "Short")
+	golden.RequireEqual(t, []byte(table.String()))
+}
+
+func TestColumnsPriority(t *testing.T) {
+	table := New().
+		Width(20).
+		StyleFunc(TableStyle).
+		Columns(
+			NewColumn("ID"),
+			NewColumn("Name").MinWidth(8).Priority(2),
+			NewColumn("Description").MinWidth(12).Priority(1),
+		).
+		Rows(columnRows...)
+
+	out := table.String()
+	if hidden := table.HiddenColumns(); !slices.Equal(hidden, []int{2}) {
+		t.Fatalf("expected column 2 to be hidden, got %v", hidden)
+	}
+	if w := lipgloss.Width(out); w > 20 {
+		t.Fatalf("expected a table at most 20 cells wide, got %d", w)
+	}
+	golden.RequireEqual(t, []byte(out))
+
+	table.Width(0)
+	_ = table.String()
+	if hidden := table.HiddenColumns(); len(hidden) != 0 {
+		t.Fatalf("expected no hidden columns, got %v", hidden)
+	}
+}
+
+func TestColumnAlignDecimal(t *testing.T) {
+	table := New().
+		Columns(
+			NewColumn("Item"),
+			NewColumn("Price").AlignDecimal('.'),
+			NewColumn("Size").AlignDecimal('.').Align(lipgloss.Left),
+		).
+		Row("Coffee", "3.5", "250 ml").
+		Row("Croissant", "2.25", "1.5 kg").
+		Row("Cake", "12", "1,200 g").
+		Row("Tea", "n/a", "0.33 l").
+		Aggregate(1, Sum).
+		Footers("Total")
+
+	golden.RequireEqual(t, []byte(table.String()))
+}
+
+func TestColumnAlignDecimalCursor(t *testing.T) {
+	table := New().
+		Columns(NewColumn("Item"), NewColumn("Price").AlignDecimal('.')).
+		Row("Coffee", "3.5").
+		Row("Croissant", "2.25").
+		Row("Cake", "12").
+		Row("Tea", "1.8").
+		Aggregate(1, Sum).
+		Footers("Total").
+		Height(7).
+		Cursor(3).
+		CursorStyle(lipgloss.NewStyle().Bold(true))
+
+	golden.RequireEqual(t, []byte(table.String()))
+}
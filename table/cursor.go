@@ -0,0 +1,171 @@
+package table
+
+import (
+	"slices"
+
+	"charm.land/lipgloss/v2"
+)
+
+// Cursor sets the row of the cursor, or hides it when negative. The cursor
+// is clamped to the data rows.
+//
+// When the table has a height, rendering it scrolls the table so that the
+// cursor is visible, updating its offset (see [Table.GetYOffset]). Rendering
+// it again without moving the cursor doesn't change the offset.
+func (t *Table) Cursor(row int) *Table {
+	t.cursor = max(row, -1)
+	if row := t.cursorRow(); row >= 0 {
+		t.cursor = row
+	}
+	return t
+}
+
+// GetCursor returns the row of the cursor, or -1 if there is no cursor.
+func (t *Table) GetCursor() int {
+	return t.cursorRow()
+}
+
+// MoveCursor moves the cursor by the given number of rows, up when negative.
+// It starts at the first row when there is no cursor.
+func (t *Table) MoveCursor(n int) *Table {
+	row := t.cursorRow()
+	if row < 0 {
+		t.cursor = 0
+		return t
+	}
+	t.cursor = max(row+n, 0)
+	return t
+}
+
+// CursorStyle sets the style of the row of the cursor, layered over the
+// styles of its cells. Padding and margins are kept from [StyleFunc], so
+// that the cells keep their size.
+func (t *Table) CursorStyle(style lipgloss.Style) *Table {
+	t.cursorStyle = style
+	return t
+}
+
+// SelectedStyle sets the style of the selected rows, layered over the styles
+// of their cells. Padding and margins are kept from [StyleFunc], so that the
+// cells keep their size. The cursor style is layered over it.
+func (t *Table) SelectedStyle(style lipgloss.Style) *Table {
+	t.selectedStyle = style
+	return t
+}
+
+// Select adds the given rows to the selection.
+func (t *Table) Select(rows ...int) *Table {
+	if t.selected == nil {
+		t.selected = make(map[int]bool)
+	}
+	for _, row := range rows {
+		t.selected[row] = true
+	}
+	return t
+}
+
+// Deselect removes the given rows from the selection.
+func (t *Table) Deselect(rows ...int) *Table {
+	for _, row := range rows {
+		delete(t.selected, row)
+	}
+	return t
+}
+
+// ToggleSelected selects the given row if it isn't selected, and deselects it
+// otherwise.
+func (t *Table) ToggleSelected(row int) *Table {
+	if t.selected[row] {
+		return t.Deselect(row)
+	}
+	return t.Select(row)
+}
+
+// ClearSelection deselects all the rows.
+func (t *Table) ClearSelection() *Table {
+	t.selected = nil
+	return t
+}
+
+// IsSelected returns whether the given row is selected.
+func (t *Table) IsSelected(row int) bool {
+	return t.selected[row]
+}
+
+// GetSelected returns the selected rows, in order.
+func (t *Table) GetSelected() []int {
+	rows := make([]int, 0, len(t.selected))
+	for row := range t.selected {
+		rows = append(rows, row)
+	}
+	slices.Sort(rows)
+	return rows
+}
+
+// cursorRow returns the row of the cursor, clamped to the data rows, or -1 if
+// there is no cursor.
+func (t *Table) cursorRow() int {
+	data := t.data
+	if w, ok := data.(*window); ok {
+		data = w.data
+	}
+	if t.cursor < 0 || data == nil || data.Rows() == 0 {
+		return -1
+	}
+	return min(t.cursor, data.Rows()-1)
+}
+
+// rowStyle layers the selected and cursor styles over the style of a cell of
+// the given data row.
+func (t *Table) rowStyle(style lipgloss.Style, row int) lipgloss.Style {
+	if row < 0 {
+		return style
+	}
+	if t.selected[row] {
+		style = layer(style, t.selectedStyle)
+	}
+	if row == t.cursorRow() {
+		style = layer(style, t.cursorStyle)
+	}
+	return style
+}
+
+// layer returns a style with the given style layered over it, except for its
+// padding and margins.
+func layer(style, over lipgloss.Style) lipgloss.Style {
+	top, right, bottom, left := style.GetPadding()
+	marginTop, marginRight, marginBottom, marginLeft := style.GetMargin()
+	layered := over.Inherit(style).
+		Padding(top, right, bottom, left).
+		Margin(marginTop, marginRight, marginBottom, marginLeft)
+
+	// Hyperlinks aren't inherited.
+	if link, _ := over.GetHyperlink(); link == "" {
+		if link, params := style.GetHyperlink(); link != "" {
+			layered = layered.Hyperlink(link, params)
+		}
+	}
+	return layered
+}
+
+// scrollToCursor adjusts the offset of the resizer so that the row of the
+// cursor is visible, and returns the visible row indexes.
+func (t *Table) scrollToCursor(r *resizer) (firstVisibleRowIndex, lastVisibleRowIndex, overflowHeight int) {
+	firstVisibleRowIndex, lastVisibleRowIndex, overflowHeight = r.visibleRowIndexes()
+	cursor := t.cursorRow()
+	if cursor < 0 || !r.useManualHeight {
+		return firstVisibleRowIndex, lastVisibleRowIndex, overflowHeight
+	}
+	cursor -= t.windowStart
+
+	if cursor < firstVisibleRowIndex {
+		r.yOffset = cursor
+		firstVisibleRowIndex, lastVisibleRowIndex, overflowHeight = r.visibleRowIndexes()
+	}
+	for lastVisibleRowIndex != -2 && cursor > lastVisibleRowIndex && r.yOffset < cursor {
+		r.yOffset++
+		firstVisibleRowIndex, lastVisibleRowIndex, overflowHeight = r.visibleRowIndexes()
+	}
+	t.yOffset = r.yOffset
+	return firstVisibleRowIndex, lastVisibleRowIndex, overflowHeight
+}
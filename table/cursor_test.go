@@ -0,0 +1,123 @@
+package table
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/exp/golden"
+)
+
+func TestCursorStyles(t *testing.T) {
+	table := New().
+		Headers("Name", "Age of Person", "Location").
+		Rows(
+			[]string{"Kini", "40", "New York"},
+			[]string{"Eli", "30", "London"},
+			[]string{"Iris", "20", "Paris"},
+		).
+		StyleFunc(func(_, _ int) lipgloss.Style {
+			return lipgloss.NewStyle().Padding(0, 1).Foreground(lipgloss.Color("252"))
+		}).
+		SelectedStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("212"))).
+		CursorStyle(lipgloss.NewStyle().Reverse(true).Padding(0, 5)).
+		Select(0, 2).
+		Cursor(2)
+
+	golden.RequireEqual(t, []byte(table.String()))
+}
+
+func TestCursorScroll(t *testing.T) {
+	table := New().Headers("#").Height(8)
+	for i := range 20 {
+		table.Row(strconv.Itoa(i))
+	}
+
+	tests := []struct {
+		move          int
+		cursor        int
+		first, offset int
+	}{
+		{move: 0, cursor: 0, first: 0, offset: 0},
+		{move: 5, cursor: 5, first: 3, offset: 3},
+		{move: 100, cursor: 19, first: 16, offset: 16},
+		{move: -15, cursor: 4, first: 4, offset: 4},
+		{move: -100, cursor: 0, first: 0, offset: 0},
+	}
+	for _, tc := range tests {
+		table.MoveCursor(tc.move)
+		_ = table.String()
+		if cursor := table.GetCursor(); cursor != tc.cursor {
+			t.Fatalf("expected the cursor at %d, got %d", tc.cursor, cursor)
+		}
+		if first := table.FirstVisibleRowIndex(); first != tc.first {
+			t.Fatalf("expected the first visible row to be %d, got %d", tc.first, first)
+		}
+		if offset := table.GetYOffset(); offset != tc.offset {
+			t.Fatalf("expected an offset of %d, got %d", tc.offset, offset)
+		}
+	}
+}
+
+func TestCursorRenderTwice(t *testing.T) {
+	for _, virtualized := range []bool{false, true} {
+		table := New().Headers("#").Height(8).Virtualized(virtualized)
+		for i := range 20 {
+			table.Row(strconv.Itoa(i))
+		}
+		table.Cursor(12)
+
+		// The first render scrolls to the cursor, and the next ones render
+		// the same table.
+		first := table.String()
+		offset := table.GetYOffset()
+		if second := table.String(); second != first {
+			t.Fatalf("expected the same table when rendered twice:\n%s\n\ngot:\n%s", first, second)
+		}
+		if got := table.GetYOffset(); got != offset {
+			t.Fatalf("expected the offset to stay at %d, got %d", offset, got)
+		}
+
+		// Rendering doesn't move the cursor, even when rows are removed.
+		table.ClearRows().Row("0")
+		_ = table.String()
+		table.Rows([]string{"1"}, []string{"2"})
+		if cursor := table.GetCursor(); cursor != 2 {
+			t.Fatalf("expected the cursor at 2, got %d", cursor)
+		}
+	}
+}
+
+func TestCursorVirtualized(t *testing.T) {
+	data := &logData{rows: 1_000_000}
+	table := New().
+		Headers("Line", "Message").
+		Data(data).
+		Height(8).
+		Virtualized(true).
+		SampleRows(10).
+		Cursor(700_000)
+	_ = table.String()
+
+	if first, last := table.FirstVisibleRowIndex(), table.LastVisibleRowIndex(); first > 700_000 || last < 700_000 {
+		t.Fatalf("expected row 700000 to be visible, got rows %d to %d", first, last)
+	}
+	if data.reads > 2*(10+(8+2)+3) {
+		t.Fatalf("expected the table to only read the rendered rows, read %d cells", data.reads)
+	}
+}
+
+func TestSelection(t *testing.T) {
+	table := New().Select(4, 1).Select(7).Deselect(4).ToggleSelected(2).ToggleSelected(7)
+
+	if selected := table.GetSelected(); !slices.Equal(selected, []int{1, 2}) {
+		t.Fatalf("expected rows 1 and 2 to be selected, got %v", selected)
+	}
+	if !table.IsSelected(1) || table.IsSelected(7) {
+		t.Fatal("expected row 1 to be selected, and row 7 not to be")
+	}
+	if selected := table.ClearSelection().GetSelected(); len(selected) != 0 {
+		t.Fatalf("expected no selected rows, got %v", selected)
+	}
+}
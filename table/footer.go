@@ -0,0 +1,184 @@
+package table
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// FooterRow denotes the footer's row index used when rendering footers. Use
+// this value when looking to customize footer styles in StyleFunc.
+const FooterRow int = math.MinInt32
+
+// Aggregator computes the footer of a column from the contents of its cells.
+type Aggregator func(values []string) string
+
+// Sum is an [Aggregator] that adds up the numbers of a column.
+func Sum(values []string) string {
+	nums, decimals := numbers(values)
+	var total float64
+	for _, n := range nums {
+		total += n
+	}
+	return formatNumber(total, decimals)
+}
+
+// Average is an [Aggregator] that computes the mean of the numbers of a
+// column. It shows at least two decimals, unless the mean is a whole number.
+func Average(values []string) string {
+	nums, decimals := numbers(values)
+	if len(nums) == 0 {
+		return ""
+	}
+	var total float64
+	for _, n := range nums {
+		total += n
+	}
+	mean := total / float64(len(nums))
+	if mean != math.Trunc(mean) {
+		decimals = max(decimals, 2) //nolint:mnd
+	}
+	return formatNumber(mean, decimals)
+}
+
+// Count is an [Aggregator] that counts the non-empty cells of a column.
+func Count(values []string) string {
+	var count int
+	for _, v := range values {
+		if strings.TrimSpace(ansi.Strip(v)) != "" {
+			count++
+		}
+	}
+	return strconv.Itoa(count)
+}
+
+// Min is an [Aggregator] that finds the smallest number of a column.
+func Min(values []string) string {
+	nums, decimals := numbers(values)
+	if len(nums) == 0 {
+		return ""
+	}
+	m := nums[0]
+	for _, n := range nums[1:] {
+		m = min(m, n)
+	}
+	return formatNumber(m, decimals)
+}
+
+// Max is an [Aggregator] that finds the largest number of a column.
+func Max(values []string) string {
+	nums, decimals := numbers(values)
+	if len(nums) == 0 {
+		return ""
+	}
+	m := nums[0]
+	for _, n := range nums[1:] {
+		m = max(m, n)
+	}
+	return formatNumber(m, decimals)
+}
+
+// numbers parses the numbers of a column, ignoring styles, surrounding spaces,
+// thousands separators and cells that aren't numbers. It also returns the
+// largest number of decimals found.
+func numbers(values []string) (nums []float64, decimals int) {
+	for _, v := range values {
+		v = strings.ReplaceAll(strings.TrimSpace(ansi.Strip(v)), ",", "")
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			continue
+		}
+		nums = append(nums, n)
+		if i := strings.IndexByte(v, '.'); i >= 0 {
+			decimals = max(decimals, len(v)-i-1)
+		}
+	}
+	return nums, decimals
+}
+
+// formatNumber formats a number with the given number of decimals.
+func formatNumber(n float64, decimals int) string {
+	return strconv.FormatFloat(n, 'f', decimals, 64)
+}
+
+// Footers sets the table footers, rendered below the data rows. The footers
+// stay visible when the table is scrolled.
+func (t *Table) Footers(footers ...string) *Table {
+	t.footers = footers
+	return t
+}
+
+// GetFooters returns the table footers.
+func (t *Table) GetFooters() []string {
+	return t.footers
+}
+
+// Aggregate sets the footer of a column to the result of an aggregator over
+// the contents of the column, like [Sum] or [Average]. It takes precedence
+// over the footer set with [Table.Footers]. A nil aggregator removes it.
+func (t *Table) Aggregate(col int, aggregator Aggregator) *Table {
+	if t.aggregators == nil {
+		t.aggregators = make(map[int]Aggregator)
+	}
+	if aggregator == nil {
+		delete(t.aggregators, col)
+	} else {
+		t.aggregators[col] = aggregator
+	}
+	return t
+}
+
+// BorderFooter sets the footer separator border.
+func (t *Table) BorderFooter(v bool) *Table {
+	t.borderFooter = v
+	return t
+}
+
+// GetBorderFooter gets the footer separator border.
+func (t *Table) GetBorderFooter() bool {
+	return t.borderFooter
+}
+
+// hasFooter returns whether the table has a footer.
+func (t *Table) hasFooter() bool {
+	return len(t.footers) > 0 || len(t.aggregators) > 0
+}
+
+// footerRow returns the layout row of the footer, or -1 if the table has no
+// footer.
+func (t *Table) footerRow() int {
+	if !t.hasFooter() {
+		return -1
+	}
+	return t.headerRows() + t.data.Rows()
+}
+
+// footerContents returns the contents of the footer of each column, or nil if
+// the table has no footer.
+func (t *Table) footerContents() []string {
+	if !t.hasFooter() {
+		return nil
+	}
+	footers := make([]string, t.columnCount())
+	for c := range footers {
+		footers[c] = t.footer(c)
+	}
+	return footers
+}
+
+// footer returns the contents of the footer of a column.
+func (t *Table) footer(col int) string {
+	if aggregator, ok := t.aggregators[col]; ok {
+		values := make([]string, t.data.Rows())
+		for i := range values {
+			values[i] = t.cellAt(i, col)
+		}
+		return aggregator(values)
+	}
+	if col < len(t.footers) {
+		return t.footers[col]
+	}
+	return ""
+}
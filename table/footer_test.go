@@ -0,0 +1,102 @@
+package table
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/exp/golden"
+)
+
+var footerRows = [][]string{
+	{"Apples", "12", "0.50"},
+	{"Bananas", "7", "0.25"},
+	{"Cherries", "1,200", "0.1"},
+	{"Dates", "", "2.75"},
+	{"Elderberries", "30", "1.20"},
+}
+
+func TestFooters(t *testing.T) {
+	table := New().
+		StyleFunc(TableStyle).
+		Columns(NewColumn("Fruit"), NewColumn("Count").Align(lipgloss.Right), NewColumn("Price").Align(lipgloss.Right)).
+		Rows(footerRows...).
+		Footers("Total").
+		Aggregate(1, Sum).
+		Aggregate(2, Average)
+	golden.RequireEqual(t, []byte(table.String()))
+}
+
+func TestFootersYOffset(t *testing.T) {
+	table := New().
+		StyleFunc(TableStyle).
+		Headers("Fruit", "Count", "Price").
+		Rows(footerRows...).
+		Footers("Total", "5 rows").
+		Height(9).
+		YOffset(2)
+	out := table.String()
+	if h := lipgloss.Height(out); h != 9 {
+		t.Fatalf("expected a height of 9, got %d", h)
+	}
+	golden.RequireEqual(t, []byte(out))
+}
+
+func TestFootersStyle(t *testing.T) {
+	table := New().
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == FooterRow {
+				return lipgloss.NewStyle().Padding(0, 1).Bold(true)
+			}
+			return lipgloss.NewStyle().Padding(0, 1)
+		}).
+		BorderFooter(false).
+		BorderRow(true).
+		Rows(footerRows...).
+		Footers("Total").
+		Aggregate(1, Max)
+	golden.RequireEqual(t, []byte(table.String()))
+}
+
+func TestAggregators(t *testing.T) {
+	values := []string{"1,000", " 2.5 ", "abc", "", "-3", lipgloss.NewStyle().Bold(true).Render("4")}
+	tests := []struct {
+		name       string
+		aggregator Aggregator
+		expected   string
+	}{
+		{"Sum", Sum, "1003.5"},
+		{"Average", Average, "250.88"},
+		{"Count", Count, "5"},
+		{"Min", Min, "-3.0"},
+		{"Max", Max, "1000.0"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.aggregator(values); got != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+
+	if got := Average([]string{"2", "4"}); got != "3" {
+		t.Fatalf("expected a whole average, got %q", got)
+	}
+	if got := Max(nil); got != "" {
+		t.Fatalf("expected no maximum, got %q", got)
+	}
+}
+
+func TestFootersOnly(t *testing.T) {
+	table := New().Footers("Total", "0 rows")
+	golden.RequireEqual(t, []byte(table.String()))
+}
+
+func TestFootersWiderThanData(t *testing.T) {
+	table := New().
+		StyleFunc(TableStyle).
+		Headers("Fruit").
+		Row("Apples", "12").
+		Footers("Total", "", "Average").
+		Aggregate(3, Count)
+	golden.RequireEqual(t, []byte(table.String()))
+}
@@ -0,0 +1,139 @@
+package table
+
+// HeaderGroup is a header label spanning several columns, rendered above the
+// headers. See [Table.HeaderGroups].
+type HeaderGroup struct {
+	// Label is the text of the group. Headers below a group without a label
+	// extend upwards to take its place.
+	Label string
+
+	// Columns is the number of columns the group spans.
+	Columns int
+}
+
+// Group returns a [HeaderGroup] with the given label, spanning the given
+// number of columns.
+func Group(label string, columns int) HeaderGroup {
+	return HeaderGroup{Label: label, Columns: columns}
+}
+
+// HeaderGroupRow returns the row index of a level of header groups, used
+// when rendering header groups. Use this value when looking to customize
+// header group styles in StyleFunc. Level 0 is right above the headers.
+func HeaderGroupRow(level int) int {
+	return HeaderRow - 1 - level
+}
+
+// HeaderGroups adds a row of header groups above the headers, and above any
+// groups added before. Groups are laid out from the first column, and
+// columns after the last group aren't grouped. Header groups are only
+// rendered when the table has headers.
+//
+// Example:
+//
+//	t := table.New().
+//	    Headers("PID", "RSS", "VSZ", "User", "System").
+//	    HeaderGroups(table.Group("", 1), table.Group("Memory", 2), table.Group("CPU", 2))
+func (t *Table) HeaderGroups(groups ...HeaderGroup) *Table {
+	t.groups = append(t.groups, groups)
+	return t
+}
+
+// ClearHeaderGroups removes all the header groups.
+func (t *Table) ClearHeaderGroups() *Table {
+	t.groups = nil
+	return t
+}
+
+// GetHeaderGroups returns the rows of header groups, starting with the one
+// right above the headers.
+func (t *Table) GetHeaderGroups() [][]HeaderGroup {
+	return t.groups
+}
+
+// headerRows returns the number of header rows, including header groups.
+func (t *Table) headerRows() int {
+	if len(t.headers) == 0 {
+		return 0
+	}
+	return len(t.groups) + 1
+}
+
+// groupLabel returns the label of the group of the given level that starts at
+// the given column.
+func (t *Table) groupLabel(level, col int) string {
+	var first int
+	for _, g := range t.groups[level] {
+		if first == col {
+			return g.Label
+		}
+		first += max(g.Columns, 1)
+	}
+	return ""
+}
+
+// layoutGroups lays out the header groups over the visible columns, above the
+// headers. Groups without a label extend the cells below them upwards.
+func (t *Table) layoutGroups(layout [][]*cellSpan) {
+	header := t.headerRows() - 1
+
+	for level, groups := range t.groups {
+		i := header - 1 - level
+
+		// positions returns the range of visible columns within the given
+		// columns.
+		positions := func(first, last int) (start, end int) {
+			start, end = -1, -1
+			for p, c := range t.visible {
+				if c >= first && c < last {
+					if start < 0 {
+						start = p
+					}
+					end = p + 1
+				}
+			}
+			return start, end
+		}
+
+		// extend extends the cells below the given visible columns upwards,
+		// as long as they don't stick out of them.
+		extend := func(start, end int) {
+			for p := start; p < end; p++ {
+				below := layout[i+1][p]
+				if layout[i][p] != nil || below.pos < start || below.pos+below.cols > end {
+					continue
+				}
+				below.index = i
+				below.rows++
+				for q := below.pos; q < below.pos+below.cols; q++ {
+					layout[i][q] = below
+				}
+			}
+		}
+
+		var first int
+		for _, g := range groups {
+			last := first + max(g.Columns, 1)
+			start, end := positions(first, last)
+			if start >= 0 {
+				if g.Label == "" {
+					extend(start, end)
+				} else {
+					s := &cellSpan{
+						row: HeaderGroupRow(level), col: first,
+						index: i, pos: start, rows: 1, cols: end - start,
+					}
+					for p := start; p < end; p++ {
+						layout[i][p] = s
+					}
+				}
+			}
+			first = last
+		}
+
+		// Columns after the last group aren't grouped.
+		if start, end := positions(first, t.columnCount()); start >= 0 {
+			extend(start, end)
+		}
+	}
+}
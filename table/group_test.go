@@ -0,0 +1,98 @@
+package table
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/exp/golden"
+)
+
+var groupRows = [][]string{
+	{"1", "12.5M", "1.2G", "0.5", "0.1"},
+	{"42", "340M", "2.1G", "12.0", "3.4"},
+	{"1337", "1.1G", "8.4G", "88.1", "10.2"},
+}
+
+func TestHeaderGroups(t *testing.T) {
+	t.Run("Single", func(t *testing.T) {
+		table := New().
+			StyleFunc(TableStyle).
+			Headers("PID", "RSS", "VSZ", "User", "System").
+			HeaderGroups(Group("", 1), Group("Memory", 2), Group("CPU", 2)).
+			Rows(groupRows...)
+		golden.RequireEqual(t, []byte(table.String()))
+	})
+
+	t.Run("Nested", func(t *testing.T) {
+		table := New().
+			StyleFunc(TableStyle).
+			Headers("PID", "RSS", "VSZ", "User", "System").
+			HeaderGroups(Group("", 1), Group("Memory", 2), Group("CPU", 2)).
+			HeaderGroups(Group("", 1), Group("Resource usage", 4)).
+			Rows(groupRows...)
+		golden.RequireEqual(t, []byte(table.String()))
+	})
+
+	t.Run("Ungrouped", func(t *testing.T) {
+		table := New().
+			StyleFunc(TableStyle).
+			Headers("PID", "RSS", "VSZ", "User", "System").
+			HeaderGroups(Group("", 1), Group("Memory", 2)).
+			Rows(groupRows...)
+		golden.RequireEqual(t, []byte(table.String()))
+	})
+
+	t.Run("NoHeaderBorder", func(t *testing.T) {
+		table := New().
+			StyleFunc(TableStyle).
+			BorderHeader(false).
+			Headers("PID", "RSS", "VSZ", "User", "System").
+			HeaderGroups(Group("", 1), Group("Memory", 2), Group("CPU", 2)).
+			Rows(groupRows...)
+		golden.RequireEqual(t, []byte(table.String()))
+	})
+
+	t.Run("WideLabel", func(t *testing.T) {
+		table := New().
+			StyleFunc(TableStyle).
+			Headers("PID", "RSS", "VSZ").
+			HeaderGroups(Group("", 1), Group("Memory usage in bytes", 2)).
+			Rows(groupRows...)
+		golden.RequireEqual(t, []byte(table.String()))
+	})
+
+	t.Run("Height", func(t *testing.T) {
+		table := New().
+			StyleFunc(TableStyle).
+			Headers("PID", "RSS", "VSZ", "User", "System").
+			HeaderGroups(Group("", 1), Group("Memory", 2), Group("CPU", 2)).
+			Rows(groupRows...).
+			Height(9)
+		golden.RequireEqual(t, []byte(table.String()))
+	})
+}
+
+func TestHeaderGroupsStyleFunc(t *testing.T) {
+	var rows []int
+	table := New().
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row < HeaderRow {
+				rows = append(rows, row)
+				return lipgloss.NewStyle().Bold(true)
+			}
+			return lipgloss.NewStyle()
+		}).
+		Headers("A", "B", "C").
+		HeaderGroups(Group("", 1), Group("Inner", 2)).
+		HeaderGroups(Group("Outer", 3))
+	_ = table.String()
+
+	var inner, outer bool
+	for _, row := range rows {
+		inner = inner || row == HeaderGroupRow(0)
+		outer = outer || row == HeaderGroupRow(1)
+	}
+	if !inner || !outer {
+		t.Fatalf("expected both levels of groups to be styled, got rows %v", rows)
+	}
+}
@@ -12,20 +12,34 @@ import (
 //
 // Given a user defined table width, we must ensure the table is exactly that
 // width. This must account for all borders, column, separators, and column
-// data.
+// data. The widths are bound by the column configuration (see [Column]):
+//
+//   - A column with a fixed width is never grown or shrunk.
+//   - A column never grows beyond its maximum width, and its content is
+//     wrapped or truncated instead.
+//   - A column never shrinks below its minimum width, which is at least its
+//     horizontal padding plus one cell.
+//
+// Before sizing, if the table is too narrow to fit all columns at their
+// minimum width, hideable columns are hidden one at a time, lowest priority
+// first, until the remaining ones fit. Columns without a priority are never
+// hidden. When horizontal scrolling is enabled, columns are scrolled out
+// instead of hidden.
 //
 // In the case where the table is narrower than the specified table width,
-// we simply expand the columns evenly to fit the width.
-// For example, a table with 3 columns takes up 50 characters total, and the
-// width specified is 80, we expand each column by 10 characters, adding 30
-// to the total width.
+// we grow the narrowest column that isn't at its fixed or maximum width, one
+// cell at a time, until the table fits. If every column is at its fixed or
+// maximum width, the table stays narrower than the specified width.
 //
 // In the case where the table is wider than the specified table width, we
 // _could_ simply shrink the columns evenly but this would result in data
 // being truncated (perhaps unnecessarily). The naive approach could result
 // in very poor cropping of the table data. So, instead of shrinking columns
-// evenly, we calculate the median non-whitespace length of each column, and
-// shrink the columns based on the largest median.
+// evenly, we first shrink the columns wider than half the table, then the
+// columns whose width differs the most from the median non-whitespace length
+// of their content, then the widest ones, never going below their minimum
+// width. Only if that's not enough (e.g. an impossible width budget) are the
+// minimum widths ignored.
 //
 // For example,
 //
@@ -45,9 +59,78 @@ import (
 //
 // The biggest difference is 15 - 2, so we can shrink the 2nd column by 13.
 func (t *Table) resize() {
-	hasHeaders := len(t.headers) > 0
 	rows := DataToMatrix(t.data)
-	r := newResizer(t.width, t.height, t.headers, rows)
+	t.alignDecimals(rows)
+
+	t.visible = make([]int, t.columnCount())
+	for j := range t.visible {
+		t.visible[j] = j
+	}
+
+	var r *resizer
+	if t.horizontalScroll {
+		r = t.scrollColumns(rows)
+	} else {
+		// Hide columns by priority until the remaining ones fit at their
+		// minimum width.
+		r = t.newResizer(rows)
+		for r.tableWidth > 0 && r.minTotal() > r.tableWidth {
+			pos := t.lowestPriorityColumn()
+			if pos < 0 {
+				break
+			}
+			t.visible = append(t.visible[:pos], t.visible[pos+1:]...)
+			r = t.newResizer(rows)
+		}
+	}
+
+	// A table width wasn't specified. In this case, detect according to
+	// content width.
+	if r.tableWidth <= 0 {
+		r.tableWidth = r.detectTableWidth()
+	}
+
+	t.widths, t.heights = r.optimizedWidths()
+	t.firstVisibleRowIndex, t.lastVisibleRowIndex, t.overflowHeight = t.scrollToCursor(r)
+}
+
+// newResizer creates a resizer for the visible columns of the table.
+func (t *Table) newResizer(rows [][]string) *resizer {
+	headerRows := t.headerRows()
+	t.spans = t.layoutSpans()
+
+	// Cells spanning several columns don't count towards the width of the
+	// columns they cover, and cells spanning several rows don't count
+	// towards the height of the rows they cover. They're fitted once these
+	// are sized.
+	allRows := make([][]string, len(t.spans))
+	var spans []resizerSpan
+	rowSpanned := make(map[[2]int]bool)
+	for i, row := range t.spans {
+		allRows[i] = make([]string, len(row))
+		for p, span := range row {
+			origin := span.index == i && span.pos == p
+			if origin && span.cols == 1 {
+				allRows[i][p] = t.spanContent(span, rows)
+			}
+			if !span.merged() {
+				continue
+			}
+			if origin {
+				spans = append(spans, resizerSpan{
+					row: i, col: p, rows: span.rows, cols: span.cols,
+					content: t.spanContent(span, rows),
+				})
+			}
+			if span.rows > 1 {
+				rowSpanned[[2]int{i, p}] = true
+			}
+		}
+	}
+
+	r := newResizer(t.width, t.height, headerRows, btoi(t.hasFooter()), allRows)
+	r.spans = spans
+	r.rowSpanned = rowSpanned
 	r.wrap = t.wrap
 	r.borderColumn = t.borderColumn
 	r.yPaddings = make([][]int, len(r.allRows))
@@ -60,34 +143,44 @@ func (t *Table) resize() {
 	r.borderRight = t.borderRight
 	r.borderHeader = t.borderHeader
 	r.borderRow = t.borderRow
-
-	var allRows [][]string
-	if hasHeaders {
-		allRows = append([][]string{t.headers}, rows...)
-	} else {
-		allRows = rows
-	}
+	r.borderFooter = t.borderFooter
 
 	styleFunc := t.styleFunc
 	if t.styleFunc == nil {
 		styleFunc = DefaultStyles
 	}
 
+	for j := range r.columns {
+		column := &r.columns[j]
+		column.wrap = t.wrapColumn(t.visible[j])
+		if t.visible[j] < len(t.widthHints) {
+			column.max = max(column.max, t.widthHints[t.visible[j]])
+		}
+		if c := t.column(t.visible[j]); c != nil {
+			column.fixedWidth = c.width
+			column.minWidth = c.minWidth
+			column.maxWidth = c.maxWidth
+		}
+	}
+
 	r.rowHeights = r.defaultRowHeights()
 
-	for i, row := range allRows {
+	for i, row := range r.allRows {
 		r.yPaddings[i] = make([]int, len(row))
 
 		for j := range row {
 			column := &r.columns[j]
 
-			// Making sure we're passing the right index to `styleFunc`. The header row should be `-1` and
-			// the others should start from `0`.
-			rowIndex := i
-			if hasHeaders {
-				rowIndex--
+			// Making sure we're passing the right index to `styleFunc`. The header row should be `-1`,
+			// header groups should count down from `-2`, and the others should start from `0`.
+			rowIndex := i - headerRows
+			switch {
+			case i < headerRows-1:
+				rowIndex = HeaderGroupRow(headerRows - 2 - i)
+			case i == t.footerRow():
+				rowIndex = FooterRow
 			}
-			style := styleFunc(rowIndex, j)
+			style := styleFunc(t.sourceRow(rowIndex), t.visible[j])
 
 			column.xPadding = max(column.xPadding, style.GetHorizontalFrameSize())
 			column.fixedWidth = max(column.fixedWidth, style.GetWidth())
@@ -97,14 +190,27 @@ func (t *Table) resize() {
 		}
 	}
 
-	// A table width wasn't specified. In this case, detect according to
-	// content width.
-	if r.tableWidth <= 0 {
-		r.tableWidth = r.detectTableWidth()
-	}
+	return r
+}
 
-	t.widths, t.heights = r.optimizedWidths()
-	t.firstVisibleRowIndex, t.lastVisibleRowIndex, t.overflowHeight = r.visibleRowIndexes()
+// lowestPriorityColumn returns the position of the visible column to hide
+// first, or -1 if no visible column can be hidden. On ties, the rightmost
+// column is hidden first. The last visible column is never hidden.
+func (t *Table) lowestPriorityColumn() int {
+	if len(t.visible) <= 1 {
+		return -1
+	}
+	pos := -1
+	for p, j := range t.visible {
+		c := t.column(j)
+		if c == nil || !c.hideable {
+			continue
+		}
+		if pos < 0 || c.priority <= t.column(t.visible[pos]).priority {
+			pos = p
+		}
+	}
+	return pos
 }
 
 // resizerColumn is a column in the resizer.
@@ -116,16 +222,29 @@ type resizerColumn struct {
 	rows       [][]string
 	xPadding   int // horizontal padding
 	fixedWidth int
+	minWidth   int // declared minimum width
+	maxWidth   int // declared maximum width
+	wrap       bool
+}
+
+// resizerSpan is a cell spanning several rows or columns in the resizer.
+type resizerSpan struct {
+	row, col   int
+	rows, cols int
+	content    string
 }
 
 // resizer is a table resizer.
 type resizer struct {
 	tableWidth  int
 	tableHeight int
-	headers     []string
+	headerRows  int
+	footerRows  int
 	allRows     [][]string
 	rowHeights  []int
 	columns     []resizerColumn
+	spans       []resizerSpan
+	rowSpanned  map[[2]int]bool // cells covered by spans of several rows
 
 	wrap         bool
 	borderColumn bool
@@ -139,23 +258,21 @@ type resizer struct {
 	borderRight     bool
 	borderHeader    bool
 	borderRow       bool
+	borderFooter    bool
 }
 
-// newResizer creates a new resizer.
-func newResizer(tableWidth, tableHeight int, headers []string, rows [][]string) *resizer {
+// newResizer creates a new resizer. The first rows are the header rows, and
+// the last rows the footer rows, if any.
+func newResizer(tableWidth, tableHeight, headerRows, footerRows int, allRows [][]string) *resizer {
 	r := &resizer{
 		tableWidth:  tableWidth,
 		tableHeight: tableHeight,
-		headers:     headers,
-	}
-
-	if len(headers) > 0 {
-		r.allRows = append([][]string{headers}, rows...)
-	} else {
-		r.allRows = rows
+		headerRows:  headerRows,
+		footerRows:  footerRows,
+		allRows:     allRows,
 	}
 
-	for _, row := range r.allRows {
+	for k, row := range r.allRows {
 		for i, cell := range row {
 			cellLen := lipgloss.Width(cell)
 
@@ -170,7 +287,9 @@ func newResizer(tableWidth, tableHeight int, headers []string, rows [][]string)
 				continue
 			}
 
-			r.columns[i].rows = append(r.columns[i].rows, row)
+			if k >= r.headerRows && k < len(r.allRows)-r.footerRows {
+				r.columns[i].rows = append(r.columns[i].rows, row)
+			}
 			r.columns[i].min = min(r.columns[i].min, cellLen)
 			r.columns[i].max = max(r.columns[i].max, cellLen)
 		}
@@ -209,19 +328,26 @@ func (r *resizer) expandTableWidth() (colWidths []int) {
 			break
 		}
 
-		shorterColumnIndex := 0
+		shorterColumnIndex := -1
 		shorterColumnWidth := math.MaxInt32
 
 		for j, width := range colWidths {
 			if width == r.columns[j].fixedWidth {
 				continue
 			}
+			if r.columns[j].maxWidth > 0 && width >= r.columns[j].maxWidth {
+				continue
+			}
 			if width < shorterColumnWidth {
 				shorterColumnWidth = width
 				shorterColumnIndex = j
 			}
 		}
 
+		// Every column is at its fixed or maximum width.
+		if shorterColumnIndex < 0 {
+			break
+		}
 		colWidths[shorterColumnIndex]++
 	}
 
@@ -232,9 +358,24 @@ func (r *resizer) expandTableWidth() (colWidths []int) {
 
 // minWidth returns the minimum width floor for a column: horizontal padding
 // plus at least 1 to ensure the "…" truncation marker or one character is
-// always visible. A column must never shrink below this floor.
+// always visible, or its declared minimum width if larger. A column must never
+// shrink below this floor.
 func (r *resizer) minWidth(j int) int {
-	return r.xPaddingForCol(j) + max(r.columns[j].min, 1)
+	return max(r.xPaddingForCol(j)+max(r.columns[j].min, 1), r.columns[j].minWidth)
+}
+
+// minTotal returns the width of the table with every column at its minimum
+// width floor.
+func (r *resizer) minTotal() int {
+	total := r.totalHorizontalBorder()
+	for j, col := range r.columns {
+		if col.fixedWidth > 0 {
+			total += col.fixedWidth
+		} else {
+			total += r.minWidth(j)
+		}
+	}
+	return total
 }
 
 // shrinkTableWidth shrinks the table width.
@@ -256,7 +397,7 @@ func (r *resizer) shrinkTableWidth() (colWidths []int) {
 				if width == r.columns[j].fixedWidth {
 					continue
 				}
-				if useFloor && width <= r.minWidth(j) {
+				if (useFloor && width <= r.minWidth(j)) || width <= r.columns[j].minWidth {
 					continue
 				}
 				if veryBigOnly {
@@ -294,7 +435,7 @@ func (r *resizer) shrinkTableWidth() (colWidths []int) {
 				if width == r.columns[j].fixedWidth {
 					continue
 				}
-				if useFloor && width <= r.minWidth(j) {
+				if (useFloor && width <= r.minWidth(j)) || width <= r.columns[j].minWidth {
 					continue
 				}
 				diffToMedian := width - r.columns[j].median
@@ -333,22 +474,34 @@ func (r *resizer) shrinkTableWidth() (colWidths []int) {
 // expandRowHeights expands the row heights.
 func (r *resizer) expandRowHeights(colWidths []int) {
 	r.rowHeights = r.defaultRowHeights()
-	if !r.wrap {
-		return
-	}
-	hasHeaders := len(r.headers) > 0
-
 	for i, row := range r.allRows {
 		for j, cell := range row {
 			// NOTE(@andreynering): Headers always have a height of 1 (+ padding), even when wrap is enabled.
-			if hasHeaders && i == 0 {
-				r.rowHeights[i] = 1 + r.yPaddingForCell(i, j)
+			if i < r.headerRows {
+				if r.wrap {
+					r.rowHeights[i] = 1 + r.yPaddingForCell(i, j)
+				}
+				continue
+			}
+			if !r.columns[j].wrap || r.rowSpanned[[2]int{i, j}] {
 				continue
 			}
 			height := r.detectContentHeight(cell, colWidths[j]-r.xPaddingForCol(j)) + r.yPaddingForCell(i, j)
 			r.rowHeights[i] = max(r.rowHeights[i], height)
 		}
 	}
+
+	// Grow the last row covered by spanning cells until their content fits.
+	for _, span := range r.spans {
+		if span.row < r.headerRows || !r.columns[span.col].wrap {
+			continue
+		}
+		width := r.spanWidth(span, colWidths) - r.xPaddingForCol(span.col)
+		height := r.detectContentHeight(span.content, width) + r.yPaddingForCell(span.row, span.col)
+		last := span.row + span.rows - 1
+		available := sum(r.rowHeights[span.row:last+1]) + (span.rows-1)*btoi(r.borderRow)
+		r.rowHeights[last] += max(height-available, 0)
+	}
 }
 
 // defaultRowHeights returns the default row heights.
@@ -366,16 +519,57 @@ func (r *resizer) defaultRowHeights() (rowHeights []int) {
 // maxColumnWidths returns the maximum column widths.
 func (r *resizer) maxColumnWidths() []int {
 	maxColumnWidths := make([]int, len(r.columns))
-	for i, col := range r.columns {
-		if col.fixedWidth > 0 {
-			maxColumnWidths[i] = col.fixedWidth
-		} else {
-			maxColumnWidths[i] = col.max + r.xPaddingForCol(col.index)
-		}
+	for i := range r.columns {
+		maxColumnWidths[i] = r.maxColumnWidth(i)
 	}
+	r.fitSpans(maxColumnWidths)
 	return maxColumnWidths
 }
 
+// fitSpans widens the columns covered by cells spanning several columns, one
+// cell at a time, until their content fits.
+func (r *resizer) fitSpans(colWidths []int) {
+	for _, span := range r.spans {
+		if span.cols < 2 { //nolint:mnd
+			continue
+		}
+		needed := lipgloss.Width(span.content) + r.xPaddingForCol(span.col)
+		for r.spanWidth(span, colWidths) < needed {
+			grown := false
+			for j := span.col; j < span.col+span.cols && r.spanWidth(span, colWidths) < needed; j++ {
+				col := r.columns[j]
+				if col.fixedWidth > 0 || (col.maxWidth > 0 && colWidths[j] >= col.maxWidth) {
+					continue
+				}
+				colWidths[j]++
+				grown = true
+			}
+			if !grown {
+				break
+			}
+		}
+	}
+}
+
+// spanWidth returns the width of a spanning cell given the column widths.
+func (r *resizer) spanWidth(span resizerSpan, colWidths []int) int {
+	return sum(colWidths[span.col:span.col+span.cols]) + (span.cols-1)*btoi(r.borderColumn)
+}
+
+// maxColumnWidth returns the width a column needs to fit its content, within
+// its declared constraints.
+func (r *resizer) maxColumnWidth(j int) int {
+	col := r.columns[j]
+	if col.fixedWidth > 0 {
+		return col.fixedWidth
+	}
+	width := col.max + r.xPaddingForCol(j)
+	if col.maxWidth > 0 {
+		width = min(width, col.maxWidth)
+	}
+	return max(width, col.minWidth)
+}
+
 // columnCount returns the column count.
 func (r *resizer) columnCount() int {
 	return len(r.columns)
@@ -384,24 +578,16 @@ func (r *resizer) columnCount() int {
 // maxCharCount returns the maximum character count.
 func (r *resizer) maxCharCount() int {
 	var count int
-	for _, col := range r.columns {
-		if col.fixedWidth > 0 {
-			count += col.fixedWidth - r.xPaddingForCol(col.index)
-		} else {
-			count += col.max
-		}
+	for j := range r.columns {
+		count += r.maxColumnWidth(j) - r.xPaddingForCol(j)
 	}
 	return count
 }
 
 // maxTotal returns the maximum total width.
 func (r *resizer) maxTotal() (maxTotal int) {
-	for j, column := range r.columns {
-		if column.fixedWidth > 0 {
-			maxTotal += column.fixedWidth
-		} else {
-			maxTotal += column.max + r.xPaddingForCol(j)
-		}
+	for j := range r.columns {
+		maxTotal += r.maxColumnWidth(j)
 	}
 	return
 }
@@ -459,14 +645,15 @@ func (r *resizer) visibleRowIndexes() (firstVisibleRowIndex, lastVisibleRowIndex
 		return 0, -2, 0
 	}
 
-	hasHeaders := len(r.headers) > 0
-	lastIndex := len(r.allRows) - 1 - btoi(hasHeaders)
+	lastIndex := len(r.allRows) - 1 - r.headerRows - r.footerRows
 
 	// Account for fixed elements (top/bottom borders, headers with their border).
 	available := r.tableHeight - btoi(r.borderTop) -
 		btoi(r.borderBottom) -
-		bton(hasHeaders, r.rowHeights[0]) -
-		btoi(hasHeaders && r.borderHeader)
+		sum(r.rowHeights[:r.headerRows]) -
+		r.headerRows*btoi(r.borderHeader) -
+		sum(r.rowHeights[len(r.rowHeights)-r.footerRows:]) -
+		r.footerRows*btoi(r.borderFooter)
 
 	// The first row we add does not need a row border.
 	available += btoi(r.borderRow)
@@ -477,7 +664,7 @@ func (r *resizer) visibleRowIndexes() (firstVisibleRowIndex, lastVisibleRowIndex
 
 	// First add rows at the bottom until we reach the available height, or the last row.
 	for available > 0 && lastVisibleRowIndex < lastIndex {
-		row := r.rowHeights[lastVisibleRowIndex+1+btoi(hasHeaders)] + btoi(r.borderRow)
+		row := r.rowHeights[lastVisibleRowIndex+1+r.headerRows] + btoi(r.borderRow)
 		overflow := bton(lastVisibleRowIndex+1 < lastIndex, 1+btoi(r.borderRow)+r.yPaddingForCell(lastVisibleRowIndex+2, 0))
 
 		if available-row-overflow < 0 {
@@ -491,7 +678,7 @@ func (r *resizer) visibleRowIndexes() (firstVisibleRowIndex, lastVisibleRowIndex
 	if lastVisibleRowIndex == lastIndex {
 		// Then add rows at the top until we reach the available height, or the first row.
 		for available > 0 && firstVisibleRowIndex > 0 {
-			row := r.rowHeights[firstVisibleRowIndex-1+btoi(hasHeaders)] + btoi(r.borderRow)
+			row := r.rowHeights[firstVisibleRowIndex-1+r.headerRows] + btoi(r.borderRow)
 
 			if available-row < 0 {
 				break
@@ -12,10 +12,20 @@ type Data interface {
 	Columns() int
 }
 
+// Spanner is implemented by [Data] whose cells can span several rows and
+// columns.
+type Spanner interface {
+	// Span returns the number of rows and columns spanned by the cell at the
+	// given index. The contents of the cells it covers are ignored.
+	Span(row, cell int) (rows, cols int)
+}
+
 // StringData is a string-based implementation of the Data interface.
 type StringData struct {
 	rows    [][]string
 	columns int
+	spans   map[[2]int][2]int
+	cells   map[[2]int]Cell
 }
 
 // NewStringData creates a new StringData with the given number of columns.
@@ -42,6 +52,9 @@ func (m *StringData) At(row, cell int) string {
 		return ""
 	}
 
+	if c, ok := m.cells[[2]int{row, cell}]; ok {
+		return c.String()
+	}
 	return m.rows[row][cell]
 }
 
@@ -62,6 +75,51 @@ func (m *StringData) Rows() int {
 	return len(m.rows)
 }
 
+// SetSpan makes the cell at the given index span the given number of rows
+// and columns.
+func (m *StringData) SetSpan(row, cell, rows, cols int) *StringData {
+	if m.spans == nil {
+		m.spans = make(map[[2]int][2]int)
+	}
+	m.spans[[2]int{row, cell}] = [2]int{max(rows, 1), max(cols, 1)}
+	return m
+}
+
+// Span returns the number of rows and columns spanned by the cell at the
+// given index.
+func (m *StringData) Span(row, cell int) (rows, cols int) {
+	if s, ok := m.spans[[2]int{row, cell}]; ok {
+		return s[0], s[1]
+	}
+	return 1, 1
+}
+
+// SetCell sets the rich cell at the given index, whose text replaces the
+// contents of the cell. See [RichData]. The row grows to fit the cell if
+// needed. Cells of rows that don't exist are ignored.
+func (m *StringData) SetCell(row, cell int, c Cell) *StringData {
+	if row < 0 || row >= len(m.rows) || cell < 0 {
+		return m
+	}
+	if r := m.rows[row]; cell >= len(r) {
+		// Copy the row, as it may be shared with the caller.
+		m.rows[row] = append(r[:len(r):len(r)], make([]string, cell+1-len(r))...)
+		m.columns = max(m.columns, cell+1)
+	}
+	if m.cells == nil {
+		m.cells = make(map[[2]int]Cell)
+	}
+	m.cells[[2]int{row, cell}] = c
+	return m
+}
+
+// Cell returns the rich cell at the given index, if one was set with
+// [StringData.SetCell].
+func (m *StringData) Cell(row, cell int) (Cell, bool) {
+	c, ok := m.cells[[2]int{row, cell}]
+	return c, ok
+}
+
 // Filter applies a filter on some data.
 type Filter struct {
 	data   Data
@@ -81,18 +139,36 @@ func (m *Filter) Filter(f func(row int) bool) *Filter {
 
 // At returns the row at the given index.
 func (m *Filter) At(row, cell int) string {
+	if i := m.sourceRow(row); i >= 0 {
+		return m.data.At(i, cell)
+	}
+	return ""
+}
+
+// Cell returns the rich cell at the given index, if the data implements
+// [RichData].
+func (m *Filter) Cell(row, cell int) (Cell, bool) {
+	if i := m.sourceRow(row); i >= 0 {
+		return richCell(m.data, i, cell)
+	}
+	return Cell{}, false
+}
+
+// sourceRow returns the index of a filtered row in the underlying data, or -1
+// if there is no such row.
+func (m *Filter) sourceRow(row int) int {
 	j := 0
 	for i := range m.data.Rows() {
 		if m.filter(i) {
 			if j == row {
-				return m.data.At(i, cell)
+				return i
 			}
 
 			j++
 		}
 	}
 
-	return ""
+	return -1
 }
 
 // Columns returns the number of columns in the table.
@@ -0,0 +1,110 @@
+package table
+
+// HorizontalScroll sets whether the table scrolls horizontally instead of
+// shrinking its columns to fit its width. When scrolling, columns keep the
+// width of their content, and the columns that don't fit are cut off. Use
+// [Table.XOffset] to scroll and [Table.FrozenColumns] to keep leading
+// columns in place.
+func (t *Table) HorizontalScroll(v bool) *Table {
+	t.horizontalScroll = v
+	return t
+}
+
+// GetHorizontalScroll returns whether the table scrolls horizontally.
+func (t *Table) GetHorizontalScroll() bool {
+	return t.horizontalScroll
+}
+
+// XOffset sets the number of columns the table is scrolled by, not counting
+// frozen columns. It only applies when the table scrolls horizontally.
+func (t *Table) XOffset(o int) *Table {
+	t.xOffset = max(o, 0)
+	return t
+}
+
+// GetXOffset returns the number of columns the table is scrolled by.
+func (t *Table) GetXOffset() int {
+	return t.xOffset
+}
+
+// FrozenColumns sets the number of leading columns that stay in place when
+// the table scrolls horizontally.
+func (t *Table) FrozenColumns(n int) *Table {
+	t.frozenColumns = max(n, 0)
+	return t
+}
+
+// GetFrozenColumns returns the number of leading columns that stay in place
+// when the table scrolls horizontally.
+func (t *Table) GetFrozenColumns() int {
+	return t.frozenColumns
+}
+
+// ScrollIndicators sets the indicators shown in the top and bottom borders
+// when columns are scrolled out of view on the left and on the right. The
+// defaults are "‹" and "›".
+func (t *Table) ScrollIndicators(left, right string) *Table {
+	t.scrollLeft = left
+	t.scrollRight = right
+	return t
+}
+
+// scrollColumns sets the visible columns to the frozen columns, followed by
+// the scrolled columns that fit in the table width, and returns a resizer
+// for them.
+func (t *Table) scrollColumns(rows [][]string) *resizer {
+	n := len(t.visible)
+	frozen := min(t.frozenColumns, n)
+	offset := min(t.xOffset, max(n-frozen-1, 0))
+
+	t.visible = append(t.visible[:frozen], t.visible[frozen+offset:]...)
+	t.overflowLeft = offset > 0
+	t.overflowRight = false
+
+	r := t.newResizer(rows)
+	if r.tableWidth <= 0 || len(t.visible) == 0 {
+		return r
+	}
+
+	// Keep the columns that fit at their natural width, and at least one
+	// column after the frozen ones.
+	widths := r.maxColumnWidths()
+	total := btoi(t.borderLeft) + btoi(t.borderRight) + widths[0]
+	end := 1
+	for end < len(widths) && total+btoi(t.borderColumn)+widths[end] <= r.tableWidth {
+		total += btoi(t.borderColumn) + widths[end]
+		end++
+	}
+	end = min(max(end, frozen+1), len(t.visible))
+
+	if end < len(t.visible) {
+		t.visible = t.visible[:end]
+		t.overflowRight = true
+		r = t.newResizer(rows)
+	}
+
+	// The columns aren't expanded to fill the table width.
+	r.tableWidth = min(r.tableWidth, r.detectTableWidth())
+	return r
+}
+
+// scrolledOut returns whether the columns before the visible column at the
+// given position are scrolled out, which happens after the frozen columns.
+func (t *Table) scrolledOut(pos int) bool {
+	return t.horizontalScroll && t.overflowLeft && pos == min(t.frozenColumns, len(t.visible))
+}
+
+// scrollIndicators returns the scroll indicators shown at the start and at
+// the end of the border segment of the given column, if any.
+func (t *Table) scrollIndicators(pos int) (left, right string) {
+	if !t.horizontalScroll {
+		return "", ""
+	}
+	if t.scrolledOut(pos) {
+		left = t.scrollLeft
+	}
+	if t.overflowRight && pos == len(t.visible)-1 {
+		right = t.scrollRight
+	}
+	return left, right
+}
@@ -0,0 +1,82 @@
+package table
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/exp/golden"
+)
+
+var scrollHeaders = []string{"ID", "Name", "Email", "City", "Country", "Phone"}
+
+var scrollRows = [][]string{
+	{"1", "Kini", "kini@example.com", "New York", "United States", "+1 555 0100"},
+	{"2", "Eli", "eli@example.com", "London", "United Kingdom", "+44 20 7946 0000"},
+	{"3", "Iris", "iris@example.com", "Paris", "France", "+33 1 23 45 67 89"},
+}
+
+func TestHorizontalScroll(t *testing.T) {
+	for _, offset := range []int{0, 1, 3, 10} {
+		t.Run(strconv.Itoa(offset), func(t *testing.T) {
+			table := New().
+				StyleFunc(TableStyle).
+				Headers(scrollHeaders...).
+				Rows(scrollRows...).
+				Width(50).
+				HorizontalScroll(true).
+				FrozenColumns(1).
+				XOffset(offset)
+			out := table.String()
+			if w := lipgloss.Width(out); w > 50 {
+				t.Fatalf("expected a width of at most 50, got %d", w)
+			}
+			golden.RequireEqual(t, []byte(out))
+		})
+	}
+}
+
+func TestHorizontalScrollHiddenColumns(t *testing.T) {
+	table := New().
+		Headers(scrollHeaders...).
+		Rows(scrollRows...).
+		Width(40).
+		HorizontalScroll(true).
+		FrozenColumns(2).
+		XOffset(1)
+	_ = table.String()
+
+	if hidden := table.HiddenColumns(); !slices.Equal(hidden, []int{2, 5}) {
+		t.Fatalf("expected columns 2 and 5 to be hidden, got %v", hidden)
+	}
+}
+
+func TestHorizontalScrollIndicators(t *testing.T) {
+	table := New().
+		Border(lipgloss.RoundedBorder()).
+		StyleFunc(TableStyle).
+		Headers(scrollHeaders...).
+		Rows(scrollRows...).
+		Width(40).
+		HorizontalScroll(true).
+		ScrollIndicators("<", ">").
+		XOffset(2)
+	golden.RequireEqual(t, []byte(table.String()))
+}
+
+func TestHorizontalScrollSpan(t *testing.T) {
+	// The span covers the first three columns, but not the third one once
+	// the second one is scrolled out.
+	data := NewStringData(
+		[]string{"a", "b", "c", "d"},
+		[]string{"e", "f", "g", "h"},
+	).SetSpan(0, 0, 1, 3)
+	table := New().
+		Data(data).
+		Width(40).
+		HorizontalScroll(true).
+		FrozenColumns(1).
+		XOffset(1)
+	golden.RequireEqual(t, []byte(table.String()))
+}
@@ -0,0 +1,252 @@
+package table
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Order is the order in which a column is sorted.
+type Order int
+
+// Sort orders.
+const (
+	Ascending Order = iota
+	Descending
+)
+
+// Comparator compares the contents of two cells. It returns a negative number
+// when a comes before b, a positive number when a comes after b, and zero
+// when they're equal.
+type Comparator func(a, b string) int
+
+// Sorted is implemented by [Data] sorted by some of its columns, like [Sort].
+// Tables show an indicator in the headers of sorted columns.
+type Sorted interface {
+	// SortOrder returns the order in which a column is sorted, and whether
+	// it's sorted at all.
+	SortOrder(col int) (order Order, ok bool)
+}
+
+// notValue is returned by [Numeric] and [Date] when a isn't a value but b
+// is, and negated when b isn't a value but a is. [Sort] keeps such cells last
+// in both orders.
+const notValue = math.MaxInt
+
+// sortKey is a column to sort by.
+type sortKey struct {
+	col     int
+	order   Order
+	compare Comparator
+}
+
+// Sort sorts some data by one or more columns, without modifying it.
+type Sort struct {
+	data  Data
+	keys  []sortKey
+	index []int
+}
+
+// NewSort initializes a new Sort.
+func NewSort(data Data) *Sort {
+	return &Sort{data: data}
+}
+
+// By sorts the data by the given column, in the given order, comparing cells
+// with the given comparator, or [Natural] if nil. Cells of [RichData] are
+// compared by their sort key, when set. When called several times,
+// later columns break the ties of earlier ones.
+func (m *Sort) By(col int, order Order, compare Comparator) *Sort {
+	if compare == nil {
+		compare = Natural
+	}
+	m.keys = append(m.keys, sortKey{col: col, order: order, compare: compare})
+	m.index = nil
+	return m
+}
+
+// Clear removes all the columns to sort by.
+func (m *Sort) Clear() *Sort {
+	m.keys = nil
+	m.index = nil
+	return m
+}
+
+// Refresh sorts the data again. The data is sorted again automatically when
+// its number of rows changes, but not when rows change in place.
+func (m *Sort) Refresh() *Sort {
+	m.index = nil
+	return m
+}
+
+// SortOrder returns the order in which a column is sorted, and whether it's
+// sorted at all.
+func (m *Sort) SortOrder(col int) (Order, bool) {
+	for _, k := range m.keys {
+		if k.col == col {
+			return k.order, true
+		}
+	}
+	return Ascending, false
+}
+
+// SourceRow returns the index of a sorted row in the underlying data.
+func (m *Sort) SourceRow(row int) int {
+	index := m.sorted()
+	if row < 0 || row >= len(index) {
+		return -1
+	}
+	return index[row]
+}
+
+// At returns the row at the given index.
+func (m *Sort) At(row, cell int) string {
+	index := m.sorted()
+	if row < 0 || row >= len(index) {
+		return ""
+	}
+	return m.data.At(index[row], cell)
+}
+
+// Cell returns the rich cell at the given index, if the data implements
+// [RichData].
+func (m *Sort) Cell(row, cell int) (Cell, bool) {
+	index := m.sorted()
+	if row < 0 || row >= len(index) {
+		return Cell{}, false
+	}
+	return richCell(m.data, index[row], cell)
+}
+
+// Columns returns the number of columns in the table.
+func (m *Sort) Columns() int {
+	return m.data.Columns()
+}
+
+// Rows returns the number of rows in the table.
+func (m *Sort) Rows() int {
+	return m.data.Rows()
+}
+
+// sorted returns the indexes of the rows of the data in sorted order.
+func (m *Sort) sorted() []int {
+	rows := m.data.Rows()
+	if m.index != nil && len(m.index) == rows {
+		return m.index
+	}
+
+	m.index = make([]int, rows)
+	for i := range m.index {
+		m.index[i] = i
+	}
+	slices.SortStableFunc(m.index, func(a, b int) int {
+		for _, k := range m.keys {
+			c := k.compare(sortText(m.data, a, k.col), sortText(m.data, b, k.col))
+			if k.order == Descending && c != notValue && c != -notValue {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	return m.index
+}
+
+// Natural compares cells in natural order: case-insensitively, with runs of
+// digits compared by their numeric value, so that "file2" comes before
+// "file10".
+func Natural(a, b string) int {
+	a, b = ansi.Strip(a), ansi.Strip(b)
+	ra, rb := []rune(strings.ToLower(a)), []rune(strings.ToLower(b))
+
+	var i, j int
+	for i < len(ra) && j < len(rb) {
+		if unicode.IsDigit(ra[i]) && unicode.IsDigit(rb[j]) {
+			si, sj := i, j
+			for i < len(ra) && unicode.IsDigit(ra[i]) {
+				i++
+			}
+			for j < len(rb) && unicode.IsDigit(rb[j]) {
+				j++
+			}
+			da := strings.TrimLeft(string(ra[si:i]), "0")
+			db := strings.TrimLeft(string(rb[sj:j]), "0")
+			if c := cmp.Compare(len(da), len(db)); c != 0 {
+				return c
+			}
+			if c := strings.Compare(da, db); c != 0 {
+				return c
+			}
+			continue
+		}
+		if c := cmp.Compare(ra[i], rb[j]); c != 0 {
+			return c
+		}
+		i++
+		j++
+	}
+	if c := cmp.Compare(len(ra)-i, len(rb)-j); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// Numeric compares cells as numbers, ignoring surrounding spaces and
+// thousands separators. Cells that aren't numbers come after numbers, in
+// both orders when sorted with [Sort], and compare in natural order.
+func Numeric(a, b string) int {
+	na, errA := parseNumber(a)
+	nb, errB := parseNumber(b)
+	switch {
+	case errA != nil && errB != nil:
+		return Natural(a, b)
+	case errA != nil:
+		return notValue
+	case errB != nil:
+		return -notValue
+	default:
+		return cmp.Compare(na, nb)
+	}
+}
+
+// parseNumber parses the number in a cell.
+func parseNumber(s string) (float64, error) {
+	s = strings.ReplaceAll(strings.TrimSpace(ansi.Strip(s)), ",", "")
+	return strconv.ParseFloat(s, 64)
+}
+
+// Date returns a [Comparator] that compares cells as dates, parsed with the
+// given function. Cells that can't be parsed come after dates, in both orders
+// when sorted with [Sort], and compare in natural order.
+func Date(parse func(string) (time.Time, error)) Comparator {
+	return func(a, b string) int {
+		ta, errA := parse(strings.TrimSpace(ansi.Strip(a)))
+		tb, errB := parse(strings.TrimSpace(ansi.Strip(b)))
+		switch {
+		case errA != nil && errB != nil:
+			return Natural(a, b)
+		case errA != nil:
+			return notValue
+		case errB != nil:
+			return -notValue
+		default:
+			return ta.Compare(tb)
+		}
+	}
+}
+
+// DateLayout returns a [Comparator] that compares cells as dates in the given
+// layout, as understood by [time.Parse].
+func DateLayout(layout string) Comparator {
+	return Date(func(s string) (time.Time, error) {
+		return time.Parse(layout, s)
+	})
+}
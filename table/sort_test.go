@@ -0,0 +1,100 @@
+package table
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/charmbracelet/x/exp/golden"
+)
+
+var sortRows = [][]string{
+	{"file10.txt", "1,024", "2024-03-01"},
+	{"file2.txt", "512", "2023-12-24"},
+	{"File1.txt", "-", "2024-01-15"},
+	{"file2.txt", "2,048", "unknown"},
+	{"readme.md", "64", "2022-07-04"},
+}
+
+func TestSort(t *testing.T) {
+	t.Run("Natural", func(t *testing.T) {
+		data := NewSort(NewStringData(sortRows...)).By(0, Ascending, Natural)
+		table := New().
+			StyleFunc(TableStyle).
+			Headers("Name", "Size", "Modified").
+			Data(data)
+		golden.RequireEqual(t, []byte(table.String()))
+	})
+
+	t.Run("Numeric", func(t *testing.T) {
+		data := NewSort(NewStringData(sortRows...)).By(1, Descending, Numeric)
+		table := New().
+			StyleFunc(TableStyle).
+			Headers("Name", "Size", "Modified").
+			Data(data)
+		golden.RequireEqual(t, []byte(table.String()))
+	})
+
+	t.Run("Date", func(t *testing.T) {
+		data := NewSort(NewStringData(sortRows...)).By(2, Ascending, DateLayout("2006-01-02"))
+		table := New().
+			StyleFunc(TableStyle).
+			Headers("Name", "Size", "Modified").
+			Data(data)
+		golden.RequireEqual(t, []byte(table.String()))
+	})
+
+	t.Run("DateDescending", func(t *testing.T) {
+		data := NewSort(NewStringData(sortRows...)).By(2, Descending, DateLayout("2006-01-02"))
+		table := New().
+			StyleFunc(TableStyle).
+			Headers("Name", "Size", "Modified").
+			Data(data)
+		golden.RequireEqual(t, []byte(table.String()))
+	})
+
+	t.Run("MultipleColumns", func(t *testing.T) {
+		data := NewSort(NewStringData(sortRows...)).
+			By(0, Descending, nil).
+			By(1, Ascending, Numeric)
+		table := New().
+			StyleFunc(TableStyle).
+			Headers("Name", "Size", "Modified").
+			SortIndicators("↑", "↓").
+			Width(30).
+			Data(data)
+		golden.RequireEqual(t, []byte(table.String()))
+	})
+}
+
+func TestSortView(t *testing.T) {
+	source := NewStringData(sortRows...)
+	data := NewSort(source).By(1, Ascending, Numeric)
+
+	if got := data.SourceRow(0); got != 4 {
+		t.Fatalf("expected the first sorted row to be row 4, got %d", got)
+	}
+	if got := source.At(0, 0); got != "file10.txt" {
+		t.Fatalf("expected the source to be left untouched, got %q", got)
+	}
+
+	source.Append([]string{"new.txt", "1", "2025-01-01"})
+	if got := data.At(0, 0); got != "new.txt" {
+		t.Fatalf("expected appended rows to be sorted, got %q", got)
+	}
+
+	if order, ok := data.SortOrder(1); !ok || order != Ascending {
+		t.Fatalf("expected column 1 to be sorted in ascending order")
+	}
+	if _, ok := data.SortOrder(0); ok {
+		t.Fatalf("expected column 0 not to be sorted")
+	}
+}
+
+func TestNatural(t *testing.T) {
+	values := []string{"item20", "Item3", "item100", "item3b", "item03", "alpha", ""}
+	slices.SortStableFunc(values, Natural)
+	expected := []string{"", "alpha", "Item3", "item03", "item3b", "item20", "item100"}
+	if !slices.Equal(values, expected) {
+		t.Fatalf("expected %v, got %v", expected, values)
+	}
+}
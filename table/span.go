@@ -0,0 +1,143 @@
+package table
+
+// cellSpan is a cell of the table layout. A cell may span several rows and
+// columns, in which case every position it covers points to the same
+// cellSpan.
+type cellSpan struct {
+	row, col   int // row and column of the cell contents, row is HeaderRow for headers
+	index      int // layout row of the cell, see [Table.layoutSpans]
+	pos        int // position of the first visible column covered by the cell
+	rows, cols int // number of rows and visible columns covered by the cell
+}
+
+// merged returns whether the cell spans several rows or columns.
+func (s *cellSpan) merged() bool {
+	return s.rows > 1 || s.cols > 1
+}
+
+// overflowRow is the layout row index of the overflow row, which has a cell
+// per column.
+const overflowRow = -2
+
+// layoutSpans lays out the cells of the table over its visible columns. Layout
+// rows are indexed like the row heights: the header groups and the header come
+// first, if any, followed by the data rows.
+func (t *Table) layoutSpans() [][]*cellSpan {
+	headerRows := t.headerRows()
+	rows := t.data.Rows()
+	spanner, _ := t.data.(Spanner)
+
+	layout := make([][]*cellSpan, rows+headerRows+btoi(t.hasFooter()))
+	for i := range layout {
+		layout[i] = make([]*cellSpan, len(t.visible))
+	}
+
+	if footer := t.footerRow(); footer >= 0 {
+		for p, c := range t.visible {
+			layout[footer][p] = &cellSpan{row: FooterRow, col: c, index: footer, pos: p, rows: 1, cols: 1}
+		}
+	}
+
+	if headerRows > 0 {
+		i := headerRows - 1
+		for p, c := range t.visible {
+			layout[i][p] = &cellSpan{row: HeaderRow, col: c, index: i, pos: p, rows: 1, cols: 1}
+		}
+		t.layoutGroups(layout)
+	}
+
+	for r := range rows {
+		i := r + headerRows
+		for p, c := range t.visible {
+			if layout[i][p] != nil {
+				continue
+			}
+
+			spanRows, spanCols := 1, 1
+			if spanner != nil && c < t.data.Columns() {
+				spanRows, spanCols = spanner.Span(r, c)
+			}
+			s := &cellSpan{row: r, col: c, index: i, pos: p, rows: 1, cols: 1}
+
+			// Cover the visible columns within the span, stopping at cells
+			// covered by other spans and at columns that are scrolled out.
+			for q := p + 1; q < len(t.visible) && !t.scrolledOut(q) &&
+				t.visible[q] < c+spanCols && layout[i][q] == nil; q++ {
+				s.cols++
+			}
+
+			// Cover the rows below, as long as they're free.
+		rows:
+			for s.rows < spanRows && i+s.rows < len(layout) {
+				for q := p; q < p+s.cols; q++ {
+					if layout[i+s.rows][q] != nil {
+						break rows
+					}
+				}
+				s.rows++
+			}
+
+			for y := i; y < i+s.rows; y++ {
+				for q := p; q < p+s.cols; q++ {
+					layout[y][q] = s
+				}
+			}
+		}
+	}
+
+	return layout
+}
+
+// rowSpans returns the cells of a layout row, or nil if the row doesn't
+// exist. The overflow row always has a cell per column.
+func (t *Table) rowSpans(i int) []*cellSpan {
+	if i == overflowRow {
+		spans := make([]*cellSpan, len(t.visible))
+		for p, c := range t.visible {
+			spans[p] = &cellSpan{
+				row: t.lastVisibleRowIndex + 1, col: c,
+				index: overflowRow, pos: p, rows: 1, cols: 1,
+			}
+		}
+		return spans
+	}
+	if i < 0 || i >= len(t.spans) {
+		return nil
+	}
+	return t.spans[i]
+}
+
+// spanContent returns the contents of a cell, given the data as a matrix.
+func (t *Table) spanContent(span *cellSpan, rows [][]string) string {
+	switch {
+	case span.row == HeaderRow:
+		return t.headers[span.col] + t.sortIndicator(span.col)
+	case span.row == FooterRow:
+		return t.footerValues[span.col]
+	case span.row < HeaderRow:
+		return t.groupLabel(HeaderRow-1-span.row, span.col)
+	case span.row < len(t.aligned[span.col]):
+		return t.aligned[span.col][span.row]
+	case rows == nil:
+		return t.cellAt(span.row, span.col)
+	case span.row < len(rows) && span.col < len(rows[span.row]):
+		return rows[span.row][span.col]
+	default:
+		return ""
+	}
+}
+
+// rowBorder returns the height of the border below a layout row, which cells
+// spanning several rows cover.
+func (t *Table) rowBorder(i int) int {
+	if i < t.headerRows() {
+		return btoi(t.borderHeader)
+	}
+	return btoi(t.borderRow)
+}
+
+// spanWidth returns the width of a cell, including the column separators it
+// covers.
+func (t *Table) spanWidth(s *cellSpan) int {
+	return sum(t.widths[s.pos:s.pos+s.cols]) + (s.cols-1)*btoi(t.borderColumn)
+}
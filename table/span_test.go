@@ -0,0 +1,114 @@
+package table
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/exp/golden"
+)
+
+func TestSpans(t *testing.T) {
+	data := NewStringData(
+		[]string{"Fruit", "Apple", "12", "0.50"},
+		[]string{"", "Banana", "7", "0.25"},
+		[]string{"", "Cherry", "120", "0.10"},
+		[]string{"Vegetables", "Carrot", "30", "0.20"},
+		[]string{"", "Leek", "5", "0.80"},
+		[]string{"Total", "", "174", ""},
+	).
+		SetSpan(0, 0, 3, 1).
+		SetSpan(3, 0, 2, 1).
+		SetSpan(5, 0, 1, 2).
+		SetSpan(5, 2, 1, 2)
+
+	t.Run("RowSeparators", func(t *testing.T) {
+		table := New().
+			StyleFunc(TableStyle).
+			Headers("Category", "Item", "Count", "Price").
+			Data(data).
+			BorderRow(true)
+		golden.RequireEqual(t, []byte(table.String()))
+	})
+
+	t.Run("NoRowSeparators", func(t *testing.T) {
+		table := New().
+			StyleFunc(TableStyle).
+			Headers("Category", "Item", "Count", "Price").
+			Data(data)
+		golden.RequireEqual(t, []byte(table.String()))
+	})
+
+	t.Run("NoHeaders", func(t *testing.T) {
+		table := New().
+			Border(lipgloss.RoundedBorder()).
+			StyleFunc(TableStyle).
+			Data(data).
+			BorderRow(true)
+		golden.RequireEqual(t, []byte(table.String()))
+	})
+
+	t.Run("YOffset", func(t *testing.T) {
+		table := New().
+			StyleFunc(TableStyle).
+			Headers("Category", "Item", "Count", "Price").
+			Data(data).
+			BorderRow(true).
+			Height(10).
+			YOffset(1)
+		golden.RequireEqual(t, []byte(table.String()))
+	})
+}
+
+func TestSpansWidth(t *testing.T) {
+	data := NewStringData(
+		[]string{"A long title spanning three columns", "", ""},
+		[]string{"a", "b", "c"},
+		[]string{"d", "A cell spanning two rows and wrapping", "e"},
+		[]string{"f", "", "g"},
+	).
+		SetSpan(0, 0, 1, 3).
+		SetSpan(2, 1, 2, 1)
+
+	t.Run("Expand", func(t *testing.T) {
+		table := New().
+			StyleFunc(TableStyle).
+			Data(data).
+			Wrap(true).
+			Width(40).
+			BorderRow(true)
+		golden.RequireEqual(t, []byte(table.String()))
+	})
+
+	t.Run("Natural", func(t *testing.T) {
+		table := New().
+			StyleFunc(TableStyle).
+			Data(data).
+			BorderRow(true)
+		golden.RequireEqual(t, []byte(table.String()))
+	})
+}
+
+func TestStringDataSpan(t *testing.T) {
+	data := NewStringData([]string{"a", "b"}).SetSpan(0, 0, 0, 2)
+	if rows, cols := data.Span(0, 0); rows != 1 || cols != 2 {
+		t.Fatalf("expected a span of 1x2, got %dx%d", rows, cols)
+	}
+	if rows, cols := data.Span(0, 1); rows != 1 || cols != 1 {
+		t.Fatalf("expected a span of 1x1, got %dx%d", rows, cols)
+	}
+}
+
+func TestSpansBlock(t *testing.T) {
+	data := NewStringData(
+		[]string{"1", "2", "3"},
+		[]string{"4", "Merged", "", "5"},
+		[]string{"6", "", "", "7"},
+		[]string{"8", "9", "10", "11"},
+	).SetSpan(1, 1, 2, 2)
+
+	table := New().
+		StyleFunc(TableStyle).
+		Data(data).
+		BorderRow(true)
+	golden.RequireEqual(t, []byte(table.String()))
+}
@@ -54,9 +54,40 @@ type Table struct {
 	borderHeader bool
 	borderColumn bool
 	borderRow    bool
+	borderFooter bool
+
+	aggregators map[int]Aggregator
+
+	sortAscending  string
+	sortDescending string
+
+	horizontalScroll bool
+	xOffset          int
+	frozenColumns    int
+	scrollLeft       string
+	scrollRight      string
+	overflowLeft     bool
+	overflowRight    bool
+
+	virtualized bool
+	sampleRows  int
+	widthHints  []int
+	windowStart int
+
+	// aligned holds the contents of the data cells of the columns aligned
+	// on a decimal separator.
+	aligned map[int][]string
+
+	cursor        int
+	selected      map[int]bool
+	cursorStyle   lipgloss.Style
+	selectedStyle lipgloss.Style
 
 	borderStyle lipgloss.Style
 	headers     []string
+	columns     []*Column
+	groups      [][]HeaderGroup
+	footers     []string
 	data        Data
 
 	width           int
@@ -68,6 +99,17 @@ type Table struct {
 	widths  []int
 	heights []int
 
+	// visible holds the indexes of the columns being rendered, in order.
+	// widths are indexed by position in visible.
+	visible []int
+
+	// spans holds the cells of each layout row, and blocks the rendered
+	// lines of cells spanning several rows. footerValues holds the contents
+	// of the footer of each column.
+	spans        [][]*cellSpan
+	blocks       map[*cellSpan][]string
+	footerValues []string
+
 	firstVisibleRowIndex int
 	lastVisibleRowIndex  int
 	overflowHeight       int
@@ -84,11 +126,19 @@ func New() *Table {
 		borderBottom: true,
 		borderColumn: true,
 		borderHeader: true,
+		borderFooter: true,
 		borderLeft:   true,
 		borderRight:  true,
 		borderTop:    true,
 		wrap:         true,
 		data:         NewStringData(),
+
+		sortAscending:  "▲",
+		sortDescending: "▼",
+		scrollLeft:     "‹",
+		scrollRight:    "›",
+		sampleRows:     100, //nolint:mnd
+		cursor:         -1,
 	}
 }
 
@@ -114,10 +164,30 @@ func (t *Table) StyleFunc(style StyleFunc) *Table {
 
 // style returns the style for a cell based on it's position (row, column).
 func (t *Table) style(row, col int) lipgloss.Style {
-	if t.styleFunc == nil {
-		return t.baseStyle
+	var cell Cell
+	var isRich bool
+	if row >= 0 && row < t.data.Rows() && col < t.data.Columns() {
+		cell, isRich = richCell(t.data, row, col)
+	}
+
+	base := t.baseStyle
+	if row < HeaderRow && row != FooterRow {
+		// Header groups are centered over their columns.
+		base = lipgloss.NewStyle().AlignHorizontal(lipgloss.Center).Inherit(base)
+	} else if c := t.column(col); c != nil && c.alignSet {
+		base = lipgloss.NewStyle().AlignHorizontal(c.align).Inherit(base)
+	} else if (c != nil && c.decimal != 0) || cell.numeric() {
+		base = lipgloss.NewStyle().AlignHorizontal(lipgloss.Right).Inherit(base)
 	}
-	return t.styleFunc(row, col).Inherit(t.baseStyle)
+
+	style := base
+	if t.styleFunc != nil {
+		style = t.styleFunc(t.sourceRow(row), col).Inherit(base)
+	}
+	if isRich {
+		style = layer(style, cell.style())
+	}
+	return t.rowStyle(style, t.sourceRow(row))
 }
 
 // Data sets the table data.
@@ -162,6 +232,154 @@ func (t *Table) GetHeaders() []string {
 	return t.headers
 }
 
+// SortIndicators sets the indicators shown after the headers of columns
+// sorted in ascending and descending order, when the table data implements
+// [Sorted]. The defaults are "▲" and "▼". Empty indicators aren't shown.
+func (t *Table) SortIndicators(ascending, descending string) *Table {
+	t.sortAscending = ascending
+	t.sortDescending = descending
+	return t
+}
+
+// sortIndicator returns the sort indicator of a column, with a leading
+// space, or an empty string if the column isn't sorted.
+func (t *Table) sortIndicator(col int) string {
+	sorted, ok := t.data.(Sorted)
+	if !ok {
+		return ""
+	}
+	order, ok := sorted.SortOrder(col)
+	if !ok {
+		return ""
+	}
+	indicator := t.sortAscending
+	if order == Descending {
+		indicator = t.sortDescending
+	}
+	if indicator == "" {
+		return ""
+	}
+	return " " + indicator
+}
+
+// Columns sets the column configuration of the table. The headers of the
+// columns replace the table headers, unless they are all empty.
+func (t *Table) Columns(columns ...*Column) *Table {
+	t.columns = columns
+
+	var headers []string
+	var hasHeaders bool
+	for _, c := range columns {
+		var header string
+		if c != nil {
+			header = c.header
+		}
+		hasHeaders = hasHeaders || header != ""
+		headers = append(headers, header)
+	}
+	if hasHeaders {
+		t.headers = headers
+	}
+	return t
+}
+
+// GetColumns returns the column configuration of the table.
+func (t *Table) GetColumns() []*Column {
+	return t.columns
+}
+
+// HiddenColumns returns the indexes of the columns that were hidden the last
+// time the table was rendered because it was too narrow. See
+// [Column.Priority].
+func (t *Table) HiddenColumns() []int {
+	var hidden []int
+	for j, p := 0, 0; j < t.columnCount(); j++ {
+		if p < len(t.visible) && t.visible[p] == j {
+			p++
+			continue
+		}
+		hidden = append(hidden, j)
+	}
+	return hidden
+}
+
+// column returns the configuration of a column, or nil if the column isn't
+// configured.
+func (t *Table) column(j int) *Column {
+	if j < 0 || j >= len(t.columns) {
+		return nil
+	}
+	return t.columns[j]
+}
+
+// columnCount returns the number of columns of the table, including the
+// columns that only have a footer.
+func (t *Table) columnCount() int {
+	n := max(len(t.headers), len(t.footers))
+	if t.data != nil {
+		n = max(n, t.data.Columns())
+	}
+	for col := range t.aggregators {
+		n = max(n, col+1)
+	}
+	return n
+}
+
+// wrapColumn returns whether the content of a column wraps.
+func (t *Table) wrapColumn(j int) bool {
+	if c := t.column(j); c != nil && c.wrapSet {
+		return c.wrap
+	}
+	return t.wrap
+}
+
+// cellAt returns the contents of a data cell, or an empty string if the
+// column is beyond the data.
+func (t *Table) cellAt(row, col int) string {
+	if col >= t.data.Columns() {
+		return ""
+	}
+	return t.data.At(row, col)
+}
+
+// alignDecimals lines the cells and footers of the columns aligned on a
+// decimal separator up, given the data as a matrix.
+func (t *Table) alignDecimals(rows [][]string) {
+	t.aligned = nil
+	for j := range t.columnCount() {
+		c := t.column(j)
+		if c == nil || c.decimal == 0 {
+			continue
+		}
+
+		values := make([]string, len(rows), len(rows)+1)
+		for i, row := range rows {
+			if j < len(row) {
+				values[i] = row[j]
+			}
+		}
+		if t.footerValues != nil {
+			values = append(values, t.footerValues[j])
+		}
+
+		// Virtualized tables only fetch a window of rows, so the numbers are
+		// also lined up with a sample of the whole data, which keeps them in
+		// place while scrolling.
+		if w, ok := t.data.(*window); ok {
+			values = append(values, t.sampleColumn(w.data, j)...)
+		}
+
+		aligned := lipgloss.AlignDecimal(c.decimal, values...)
+		if t.footerValues != nil {
+			t.footerValues[j] = aligned[len(rows)]
+		}
+		if t.aligned == nil {
+			t.aligned = make(map[int][]string)
+		}
+		t.aligned[j] = aligned[:len(rows)]
+	}
+}
+
 // Border sets the table border.
 func (t *Table) Border(border lipgloss.Border) *Table {
 	t.border = border
@@ -277,7 +495,8 @@ func (t *Table) YOffset(o int) *Table {
 	return t
 }
 
-// GetYOffset returns the table rendering offset.
+// GetYOffset returns the table rendering offset. When the table has a
+// cursor, it's updated when the table is rendered, see [Table.Cursor].
 func (t *Table) GetYOffset() int {
 	return t.yOffset
 }
@@ -309,29 +528,42 @@ func (t *Table) Wrap(w bool) *Table {
 }
 
 // String returns the table as a string.
+//
+// When the table has a height and a cursor, it scrolls the table so that the
+// cursor is visible, updating its offset (see [Table.GetYOffset]). Rendering
+// the table again without moving the cursor renders it the same.
 func (t *Table) String() string {
 	hasHeaders := len(t.headers) > 0
 	hasRows := t.data != nil && t.data.Rows() > 0
 
-	if !hasHeaders && !hasRows {
+	if !hasHeaders && !hasRows && !t.hasFooter() {
 		return ""
 	}
 
 	// Add empty cells to the headers, until it's the same length as the longest
 	// row (only if there are at headers in the first place).
 	if hasHeaders {
-		for i := len(t.headers); i < t.data.Columns(); i++ {
+		for i := len(t.headers); i < t.columnCount(); i++ {
 			t.headers = append(t.headers, "")
 		}
 	}
 
+	// Footers are computed over all the rows, even for virtualized tables,
+	// which only fetch the rows they render.
+	t.footerValues = t.footerContents()
+	if t.virtualized && t.useManualHeight {
+		restore := t.fetchWindow()
+		defer restore()
+	}
+
 	// Do all the sizing calculations for width and height.
 	t.resize()
+	t.blocks = make(map[*cellSpan][]string)
 
 	var sb strings.Builder
 
 	if t.borderTop {
-		sb.WriteString(t.constructTopBorder())
+		sb.WriteString(t.constructBorder(-1, t.firstRenderedRow(), t.border.Top))
 		sb.WriteString("\n")
 	}
 
@@ -341,7 +573,10 @@ func (t *Table) String() string {
 
 	var bottom string
 	if t.borderBottom {
-		bottom = t.constructBottomBorder()
+		bottom = t.constructBorder(t.lastRenderedRow(), -1, t.border.Bottom)
+		if footer := t.footerRow(); footer >= 0 {
+			bottom = t.constructBorder(footer, -1, t.border.Bottom)
+		}
 	}
 
 	// If there are no data rows render nothing.
@@ -359,6 +594,10 @@ func (t *Table) String() string {
 		}
 	}
 
+	if footer := t.footerRow(); footer >= 0 {
+		sb.WriteString(t.constructFooters(footer))
+	}
+
 	sb.WriteString(bottom)
 
 	return lipgloss.NewStyle().
@@ -372,7 +611,8 @@ func (t *Table) computeHeight() int {
 	hasHeaders := len(t.headers) > 0
 	return sum(t.heights) - 1 + btoi(hasHeaders) +
 		btoi(t.borderTop) + btoi(t.borderBottom) +
-		btoi(t.borderHeader) + t.data.Rows()*btoi(t.borderRow)
+		btoi(t.borderHeader)*max(t.headerRows(), 1) + t.data.Rows()*btoi(t.borderRow) +
+		btoi(t.hasFooter() && t.borderFooter)
 }
 
 // Render returns the table as a string.
@@ -380,144 +620,204 @@ func (t *Table) Render() string {
 	return t.String()
 }
 
-// constructTopBorder constructs the top border for the table given it's current
-// border configuration and data.
-func (t *Table) constructTopBorder() string {
-	var s strings.Builder
-	if t.borderLeft {
-		s.WriteString(t.borderStyle.Render(t.border.TopLeft))
-	}
-	for i := range t.widths {
-		s.WriteString(t.borderStyle.Render(strings.Repeat(t.border.Top, t.widths[i])))
-		if i < len(t.widths)-1 && t.borderColumn {
-			s.WriteString(t.borderStyle.Render(t.border.MiddleTop))
-		}
+// firstRenderedRow returns the layout row rendered first.
+func (t *Table) firstRenderedRow() int {
+	if len(t.headers) > 0 {
+		return 0
 	}
-	if t.borderRight {
-		s.WriteString(t.borderStyle.Render(t.border.TopRight))
+	return t.firstVisibleRowIndex
+}
+
+// lastRenderedRow returns the layout row rendered last, before the footer.
+func (t *Table) lastRenderedRow() int {
+	switch {
+	case t.data.Rows() == 0:
+		return t.headerRows() - 1
+	case t.lastVisibleRowIndex != -2:
+		return overflowRow
+	default:
+		return t.data.Rows() - 1 + t.headerRows()
 	}
-	return s.String()
 }
 
-// constructBottomBorder constructs the bottom border for the table given it's current
-// border configuration and data.
-func (t *Table) constructBottomBorder() string {
+// constructBorder constructs a horizontal border between two layout rows,
+// given it's current border configuration. Either row may be -1 for the top
+// and bottom borders. Cells spanning both rows interrupt the border with
+// their contents.
+func (t *Table) constructBorder(above, below int, fill string) string {
+	upper, lower := t.rowSpans(above), t.rowSpans(below)
+
+	// line returns whether the border runs below the given column.
+	line := func(p int) bool {
+		return p >= len(t.widths) || upper == nil || lower == nil || upper[p] != lower[p]
+	}
+	// separated returns whether the row has a column separator to the
+	// right of the given column.
+	separated := func(spans []*cellSpan, p int) bool {
+		return spans != nil && spans[p] != spans[p+1]
+	}
+
 	var s strings.Builder
 	if t.borderLeft {
-		s.WriteString(t.borderStyle.Render(t.border.BottomLeft))
+		s.WriteString(t.borderStyle.Render(
+			t.junction(upper != nil, lower != nil, false, line(0), fill, t.border.Left)))
 	}
-	for i := range t.widths {
-		s.WriteString(t.borderStyle.Render(strings.Repeat(t.border.Bottom, t.widths[i])))
-		if i < len(t.widths)-1 && t.borderColumn {
-			s.WriteString(t.borderStyle.Render(t.border.MiddleBottom))
+	for p := 0; p < len(t.widths); {
+		next := p + 1
+		if line(p) {
+			segment := strings.Repeat(fill, t.widths[p])
+			if upper == nil || lower == nil {
+				segment = t.scrollSegment(p, fill)
+			}
+			s.WriteString(t.borderStyle.Render(segment))
+		} else {
+			s.WriteString(t.spanLine(upper[p], above))
+			next = p + upper[p].cols
+		}
+		if next < len(t.widths) && t.borderColumn {
+			s.WriteString(t.borderStyle.Render(t.junction(
+				separated(upper, next-1), separated(lower, next-1),
+				line(next-1), line(next), fill, t.border.Left)))
 		}
+		p = next
 	}
 	if t.borderRight {
-		s.WriteString(t.borderStyle.Render(t.border.BottomRight))
+		s.WriteString(t.borderStyle.Render(
+			t.junction(upper != nil, lower != nil, line(len(t.widths)-1), false, fill, t.border.Right)))
 	}
 	return s.String()
 }
 
+// scrollSegment returns the outer border segment of a column, with the
+// scroll indicators of the column, if any.
+func (t *Table) scrollSegment(p int, fill string) string {
+	left, right := t.scrollIndicators(p)
+	width := t.widths[p]
+	leftWidth, rightWidth := lipgloss.Width(left), lipgloss.Width(right)
+	if leftWidth+rightWidth > width {
+		return strings.Repeat(fill, width)
+	}
+	return left + strings.Repeat(fill, width-leftWidth-rightWidth) + right
+}
+
+// junction returns the border character joining lines in the given
+// directions. fill and vertical are the characters of horizontal and
+// vertical lines.
+func (t *Table) junction(up, down, left, right bool, fill, vertical string) string {
+	switch {
+	case up && down && left && right:
+		return t.border.Middle
+	case up && down && left:
+		return t.border.MiddleRight
+	case up && down && right:
+		return t.border.MiddleLeft
+	case up && down:
+		return vertical
+	case down && left && right:
+		return t.border.MiddleTop
+	case up && left && right:
+		return t.border.MiddleBottom
+	case down && right:
+		return t.border.TopLeft
+	case down && left:
+		return t.border.TopRight
+	case up && right:
+		return t.border.BottomLeft
+	case up && left:
+		return t.border.BottomRight
+	case up || down:
+		return vertical
+	default:
+		return fill
+	}
+}
+
 // constructHeaders constructs the headers for the table given it's current
 // header configuration and data.
 func (t *Table) constructHeaders() string {
 	var s strings.Builder
-	cells := make([]string, 0, len(t.headers)*2+1)
-	height := t.heights[0]
+	header := t.headerRows() - 1
 
-	left := strings.Repeat(t.borderStyle.Render(t.border.Left)+"\n", height)
-	if t.borderLeft {
-		cells = append(cells, left)
-	}
-
-	for j, header := range t.headers {
-		cellStyle := t.style(HeaderRow, j)
-
-		// NOTE(@andreynering): We always truncate headers.
-		header = t.truncateCell(header, HeaderRow, j)
-
-		cells = append(cells,
-			cellStyle.
-				Height(height-cellStyle.GetVerticalMargins()).
-				Width(t.widths[j]-cellStyle.GetHorizontalMargins()).
-				Render(header),
-		)
-
-		if j < len(t.headers)-1 && t.borderColumn {
-			cells = append(cells, left)
+	for i := 0; i <= header; i++ {
+		s.WriteString(t.constructCells(i, t.heights[i]))
+		if !t.borderHeader {
+			continue
 		}
-	}
 
-	if t.borderRight {
-		right := strings.Repeat(t.borderStyle.Render(t.border.Right)+"\n", height)
-		cells = append(cells, right)
-	}
-
-	for i, cell := range cells {
-		cells[i] = strings.TrimRight(cell, "\n")
-	}
-
-	s.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, cells...) + "\n")
-
-	if t.borderHeader {
-		if t.borderLeft {
-			s.WriteString(t.borderStyle.Render(t.border.MiddleLeft))
-		}
-		for i := range t.headers {
-			s.WriteString(t.borderStyle.Render(strings.Repeat(t.border.Top, t.widths[i])))
-			if i < len(t.headers)-1 && t.borderColumn {
-				s.WriteString(t.borderStyle.Render(t.border.Middle))
+		below := i + 1
+		if i == header {
+			below = overflowRow
+			if t.data.Rows() > 0 {
+				below = t.firstVisibleRowIndex + t.headerRows()
 			}
 		}
-		if t.borderRight {
-			s.WriteString(t.borderStyle.Render(t.border.MiddleRight))
-		}
+		s.WriteString(t.constructBorder(i, below, t.border.Top))
 		s.WriteString("\n")
 	}
 
 	return s.String()
 }
 
+// constructFooters constructs the footers for the table given it's current
+// footer configuration and data.
+func (t *Table) constructFooters(footer int) string {
+	var s strings.Builder
+	if above := t.lastRenderedRow(); t.borderFooter && above != -1 {
+		s.WriteString(t.constructBorder(above, footer, t.border.Top))
+		s.WriteString("\n")
+	}
+	s.WriteString(t.constructCells(footer, t.heights[footer]))
+	return s.String()
+}
+
 // constructRow constructs the row for the table given an index and row data
 // based on the current configuration. If isOverflow is true, the row is
 // rendered as an overflow row (using ellipsis).
 func (t *Table) constructRow(index int, isOverflow bool) string {
 	var s strings.Builder
-	cells := make([]string, 0, t.data.Columns()*2+1)
 
-	hasHeaders := len(t.headers) > 0
+	headerRows := t.headerRows()
 
-	var height int
 	if !isOverflow {
-		height = t.heights[index+btoi(hasHeaders)]
+		i := index + headerRows
+		s.WriteString(t.constructCells(i, t.heights[i]))
 	} else {
-		height = t.overflowHeight
+		s.WriteString(t.constructCells(overflowRow, t.overflowHeight))
+	}
+
+	if t.borderRow && !isOverflow && index < t.data.Rows()-1 {
+		below := index + 1 + headerRows
+		if index == t.lastVisibleRowIndex {
+			below = overflowRow
+		}
+		s.WriteString(t.constructBorder(index+headerRows, below, t.border.Bottom))
+		s.WriteString("\n")
 	}
 
+	return s.String()
+}
+
+// constructCells constructs the cells of a layout row, with the given
+// height, and the borders between them.
+func (t *Table) constructCells(i, height int) string {
+	spans := t.rowSpans(i)
+	cells := make([]string, 0, len(spans)*2+1)
+
 	left := strings.Repeat(t.borderStyle.Render(t.border.Left)+"\n", height)
 	if t.borderLeft {
 		cells = append(cells, left)
 	}
 
-	for c := range t.data.Columns() {
-		cell := "…"
-		if !isOverflow {
-			cell = t.data.At(index, c)
+	for p := 0; p < len(spans); {
+		span := spans[p]
+		if span.rows > 1 {
+			cells = append(cells, t.spanSegment(span, i))
+		} else {
+			cells = append(cells, t.renderCell(span, height))
 		}
 
-		cellStyle := t.style(index, c)
-		if !t.wrap {
-			cell = t.truncateCell(cell, index, c)
-		}
-		cells = append(cells, cellStyle.
-			// Account for the margins in the cell sizing.
-			Height(height-cellStyle.GetVerticalMargins()).
-			MaxHeight(height).
-			Width(t.widths[c]-cellStyle.GetHorizontalMargins()).
-			MaxWidth(t.widths[c]).
-			Render(cell))
-
-		if c < t.data.Columns()-1 && t.borderColumn {
+		p += span.cols
+		if p < len(spans) && t.borderColumn {
 			cells = append(cells, left)
 		}
 	}
@@ -531,38 +831,120 @@ func (t *Table) constructRow(index int, isOverflow bool) string {
 		cells[i] = strings.TrimRight(cell, "\n")
 	}
 
-	s.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, cells...) + "\n")
+	return lipgloss.JoinHorizontal(lipgloss.Top, cells...) + "\n"
+}
 
-	if t.borderRow && !isOverflow && index < t.data.Rows()-1 {
-		if t.borderLeft {
-			s.WriteString(t.borderStyle.Render(t.border.MiddleLeft))
-		}
-		for i := range t.widths {
-			s.WriteString(t.borderStyle.Render(strings.Repeat(t.border.Bottom, t.widths[i])))
-			if i < len(t.widths)-1 && t.borderColumn {
-				s.WriteString(t.borderStyle.Render(t.border.Middle))
-			}
-		}
-		if t.borderRight {
-			s.WriteString(t.borderStyle.Render(t.border.MiddleRight))
+// renderCell renders a cell with the given height.
+func (t *Table) renderCell(span *cellSpan, height int) string {
+	width := t.spanWidth(span)
+	cellStyle := t.style(span.row, span.col)
+
+	if span.row < 0 && span.row != FooterRow {
+		// NOTE(@andreynering): We always truncate headers.
+		header := t.truncateCell(t.spanContent(span, nil), span.row, span.col, width)
+		if indicator := t.sortIndicator(span.col); span.row == HeaderRow && indicator != "" {
+			// Keep the sort indicator visible when truncating.
+			indicatorWidth := lipgloss.Width(indicator)
+			header = t.truncateCell(t.headers[span.col], span.row, span.col, width-indicatorWidth) + indicator
 		}
-		s.WriteString("\n")
+
+		return cellStyle.
+			Height(height - cellStyle.GetVerticalMargins()).
+			Width(width - cellStyle.GetHorizontalMargins()).
+			Render(header)
 	}
 
-	return s.String()
+	cell := "…"
+	if span.index != overflowRow {
+		cell = t.spanContent(span, nil)
+	}
+	if !t.wrapColumn(span.col) {
+		cell = t.truncateCell(cell, span.row, span.col, width)
+	}
+	return cellStyle.
+		// Account for the margins in the cell sizing.
+		Height(height - cellStyle.GetVerticalMargins()).
+		MaxHeight(height).
+		Width(width - cellStyle.GetHorizontalMargins()).
+		MaxWidth(width).
+		Render(cell)
+}
+
+// spanRange returns the first and last visible layout rows of a cell.
+func (t *Table) spanRange(span *cellSpan) (top, bottom int) {
+	top, bottom = span.index, span.index+span.rows-1
+	if span.row < 0 {
+		return top, bottom
+	}
+	top = max(top, t.firstVisibleRowIndex+t.headerRows())
+	if t.lastVisibleRowIndex != -2 {
+		bottom = min(bottom, t.lastVisibleRowIndex+t.headerRows())
+	}
+	return top, bottom
 }
 
-func (t *Table) truncateCell(cell string, rowIndex, colIndex int) string {
-	hasHeaders := len(t.headers) > 0
-	height := t.heights[rowIndex+btoi(hasHeaders)]
-	cellWidth := t.widths[colIndex]
-	cellStyle := t.style(rowIndex, colIndex)
+// spanLines renders a cell spanning several rows over all its visible rows,
+// and returns its lines.
+func (t *Table) spanLines(span *cellSpan) []string {
+	if lines, ok := t.blocks[span]; ok {
+		return lines
+	}
+
+	top, bottom := t.spanRange(span)
+	height := sum(t.heights[top : bottom+1])
+	for i := top; i < bottom; i++ {
+		height += t.rowBorder(i)
+	}
+	lines := strings.Split(t.renderCell(span, height), "\n")
+	for len(lines) < height {
+		lines = append(lines, strings.Repeat(" ", t.spanWidth(span)))
+	}
+
+	t.blocks[span] = lines
+	return lines
+}
 
+// spanOffset returns the line of a cell spanning several rows at which the
+// given layout row starts.
+func (t *Table) spanOffset(span *cellSpan, i int) int {
+	top, _ := t.spanRange(span)
+	offset := sum(t.heights[top:i])
+	for j := top; j < i; j++ {
+		offset += t.rowBorder(j)
+	}
+	return offset
+}
+
+// spanSegment returns the lines of a cell spanning several rows that are
+// rendered in the given layout row.
+func (t *Table) spanSegment(span *cellSpan, i int) string {
+	offset := t.spanOffset(span, i)
+	return strings.Join(t.spanLines(span)[offset:offset+t.heights[i]], "\n")
+}
+
+// spanLine returns the line of a cell spanning several rows that is rendered
+// in the row border below the given layout row.
+func (t *Table) spanLine(span *cellSpan, i int) string {
+	return t.spanLines(span)[t.spanOffset(span, i)+t.heights[i]]
+}
+
+// truncateCell truncates the contents of a cell to fit the given width.
+func (t *Table) truncateCell(cell string, rowIndex, colIndex, cellWidth int) string {
 	// NOTE(@andreynering): We always truncate headers to 1 line.
-	if rowIndex == HeaderRow {
-		height = 1
+	height := 1
+	switch {
+	case rowIndex == FooterRow:
+		height = t.heights[t.footerRow()]
+	case rowIndex >= 0:
+		height = t.heights[rowIndex+t.headerRows()]
+	}
+	cellStyle := t.style(rowIndex, colIndex)
+
+	tail := "…"
+	if c := t.column(colIndex); c != nil {
+		tail = c.tail
 	}
 
 	length := (cellWidth * height) - cellStyle.GetHorizontalPadding() - cellStyle.GetHorizontalMargins()
-	return ansi.Truncate(cell, length, "…")
+	return ansi.Truncate(cell, length, tail)
 }
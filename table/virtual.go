@@ -0,0 +1,172 @@
+package table
+
+import "charm.land/lipgloss/v2"
+
+// Measurer is implemented by [Data] that knows the width of its columns, like
+// data backed by a database or a log index. Virtualized tables use it instead
+// of sampling rows. See [Table.Virtualized].
+type Measurer interface {
+	// ColumnWidth returns the width of the widest cell of a column, or a
+	// negative number if it's unknown.
+	ColumnWidth(col int) int
+}
+
+// Virtualized sets whether the table only fetches the rows it renders from
+// its data, which makes rendering very large data sets cheap. It only applies
+// when the table has a height.
+//
+// Column widths can't be computed from every row of virtualized tables, so
+// they come from the widths declared with [Column.Width], from [Measurer]
+// when the data implements it, or else from a sample of rows (see
+// [Table.SampleRows]) along with the rendered rows. Numbers of columns
+// aligned with [Column.AlignDecimal] are lined up over the same sample along
+// with the rendered rows. Cells only span the rendered rows, and aggregated
+// footers still read every row.
+func (t *Table) Virtualized(v bool) *Table {
+	t.virtualized = v
+	return t
+}
+
+// GetVirtualized returns whether the table only fetches the rows it renders.
+func (t *Table) GetVirtualized() bool {
+	return t.virtualized
+}
+
+// SampleRows sets the number of rows, evenly spread over the data, sampled
+// to compute the column widths of virtualized tables. The default is 100.
+func (t *Table) SampleRows(n int) *Table {
+	t.sampleRows = max(n, 0)
+	return t
+}
+
+// GetSampleRows returns the number of rows sampled to compute the column
+// widths of virtualized tables.
+func (t *Table) GetSampleRows() int {
+	return t.sampleRows
+}
+
+// window is a window of rows of some data.
+type window struct {
+	data       Data
+	start, end int
+}
+
+// At returns the contents of the cell at the given index.
+func (w *window) At(row, cell int) string {
+	return w.data.At(row+w.start, cell)
+}
+
+// Columns returns the number of columns in the table.
+func (w *window) Columns() int {
+	return w.data.Columns()
+}
+
+// Rows returns the number of rows in the table.
+func (w *window) Rows() int {
+	return w.end - w.start
+}
+
+// Span returns the number of rows and columns spanned by the cell at the
+// given index, if the data implements [Spanner].
+func (w *window) Span(row, cell int) (rows, cols int) {
+	if spanner, ok := w.data.(Spanner); ok {
+		return spanner.Span(row+w.start, cell)
+	}
+	return 1, 1
+}
+
+// Cell returns the rich cell at the given index, if the data implements
+// [RichData].
+func (w *window) Cell(row, cell int) (Cell, bool) {
+	return richCell(w.data, row+w.start, cell)
+}
+
+// SortOrder returns the order in which a column is sorted, if the data
+// implements [Sorted].
+func (w *window) SortOrder(col int) (Order, bool) {
+	if sorted, ok := w.data.(Sorted); ok {
+		return sorted.SortOrder(col)
+	}
+	return Ascending, false
+}
+
+// fetchWindow replaces the table data with the window of rows that can be
+// rendered from the current offset, and measures the column widths over the
+// whole data. It returns a function that restores the table data, keeping
+// the offset and the visible row indexes relative to it.
+func (t *Table) fetchWindow() (restore func()) {
+	data, yOffset := t.data, t.yOffset
+	t.widthHints = t.measureColumns()
+
+	// Fetch the rows around the cursor, which the table scrolls to.
+	if cursor := t.cursorRow(); cursor >= 0 {
+		yOffset = max(min(yOffset, cursor), cursor-t.height+1)
+	}
+
+	// Every row is at least a line high, so a window of a table height of
+	// rows overflows, unless it reaches the last row. Near the end, rows
+	// above the offset fill the table instead.
+	rows := data.Rows()
+	start := max(min(yOffset, rows-t.height), 0)
+	end := min(max(yOffset, start)+t.height+2, rows) //nolint:mnd
+
+	t.data = &window{data: data, start: start, end: end}
+	t.yOffset = yOffset - start
+	t.windowStart = start
+
+	return func() {
+		t.data = data
+		t.yOffset += start
+		t.widthHints = nil
+		t.windowStart = 0
+		t.firstVisibleRowIndex += start
+		if t.lastVisibleRowIndex != -2 {
+			t.lastVisibleRowIndex += start
+		}
+	}
+}
+
+// sourceRow returns the index in the table data of a row being rendered,
+// which differs from it when only a window of rows is fetched.
+func (t *Table) sourceRow(row int) int {
+	if row < 0 {
+		return row
+	}
+	return row + t.windowStart
+}
+
+// measureColumns returns the width of the content of each column of the
+// data, measured with [Measurer] or over a sample of rows. Columns with a
+// declared width aren't measured.
+func (t *Table) measureColumns() []int {
+	measurer, _ := t.data.(Measurer)
+
+	widths := make([]int, t.data.Columns())
+	for j := range widths {
+		if c := t.column(j); c != nil && c.width > 0 {
+			continue
+		}
+		if measurer != nil {
+			if w := measurer.ColumnWidth(j); w >= 0 {
+				widths[j] = w
+				continue
+			}
+		}
+		for _, cell := range t.sampleColumn(t.data, j) {
+			widths[j] = max(widths[j], lipgloss.Width(cell))
+		}
+	}
+	return widths
+}
+
+// sampleColumn returns the cells of a column in the rows sampled evenly over
+// the data, see [Table.SampleRows].
+func (t *Table) sampleColumn(data Data, col int) []string {
+	rows := data.Rows()
+	sample := min(t.sampleRows, rows)
+	cells := make([]string, sample)
+	for k := range cells {
+		cells[k] = data.At(k*rows/sample, col)
+	}
+	return cells
+}
@@ -0,0 +1,132 @@
+package table
+
+import (
+	"fmt"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/charmbracelet/x/exp/golden"
+)
+
+// logData is a large data set that counts the cells it's asked for.
+type logData struct {
+	rows  int
+	reads int
+}
+
+func (d *logData) At(row, cell int) string {
+	d.reads++
+	if cell == 0 {
+		return fmt.Sprintf("%d", row)
+	}
+	return fmt.Sprintf("message %d", row%7)
+}
+
+func (d *logData) Rows() int    { return d.rows }
+func (d *logData) Columns() int { return 2 }
+
+// measuredLogData is a logData that knows the width of its columns.
+type measuredLogData struct {
+	logData
+}
+
+func (d *measuredLogData) ColumnWidth(col int) int {
+	if col == 0 {
+		return len(fmt.Sprintf("%d", d.rows-1))
+	}
+	return -1
+}
+
+func TestVirtualized(t *testing.T) {
+	data := &logData{rows: 1_000_000}
+	table := New().
+		Headers("Line", "Message").
+		Data(data).
+		Height(8).
+		YOffset(500_000).
+		Virtualized(true).
+		SampleRows(10).
+		StyleFunc(func(row, _ int) lipgloss.Style {
+			if row == 500_001 {
+				return lipgloss.NewStyle().Bold(true)
+			}
+			return lipgloss.NewStyle()
+		})
+
+	golden.RequireEqual(t, []byte(table.String()))
+
+	// The sample, the fetched window and the rendered cells.
+	if data.reads > 2*(10+(8+2)+3) {
+		t.Fatalf("expected the table to only read the rendered rows, read %d cells", data.reads)
+	}
+	if first, last := table.FirstVisibleRowIndex(), table.LastVisibleRowIndex(); first != 500_000 || last != 500_002 {
+		t.Fatalf("expected rows 500000 to 500002 to be visible, got %d to %d", first, last)
+	}
+}
+
+func TestVirtualizedEnd(t *testing.T) {
+	data := &logData{rows: 20}
+	table := New().
+		Headers("Line", "Message").
+		Data(data).
+		Height(8).
+		YOffset(19).
+		Virtualized(true)
+	virtualized := table.String()
+
+	table.Virtualized(false)
+	if full := table.String(); virtualized != full {
+		t.Fatalf("expected virtualized table to render like the full table:\n%s\n\ngot:\n%s", full, virtualized)
+	}
+	if first := table.FirstVisibleRowIndex(); first != 16 {
+		t.Fatalf("expected the first visible row to be 16, got %d", first)
+	}
+}
+
+func TestVirtualizedMeasurer(t *testing.T) {
+	data := &measuredLogData{logData{rows: 1_000_000}}
+	table := New().
+		Headers("Line", "Message").
+		Data(data).
+		Height(6).
+		Virtualized(true)
+
+	golden.RequireEqual(t, []byte(table.String()))
+}
+
+// priceData is a data set with a few large prices.
+type priceData struct{}
+
+func (priceData) At(row, cell int) string {
+	if cell == 0 {
+		return fmt.Sprintf("%d", row)
+	}
+	if row%10 == 0 {
+		return "1234.5"
+	}
+	return "1.25"
+}
+
+func (priceData) Rows() int    { return 100 }
+func (priceData) Columns() int { return 2 }
+
+func TestVirtualizedAlignDecimal(t *testing.T) {
+	for _, offset := range []int{1, 5} {
+		table := New().
+			Columns(NewColumn("Item"), NewColumn("Price").AlignDecimal('.')).
+			Data(priceData{}).
+			Height(6).
+			YOffset(offset).
+			Virtualized(true).
+			SampleRows(10)
+		virtualized := table.String()
+
+		// The sample has the large prices, so the numbers are lined up like
+		// they are over the whole data.
+		table.Virtualized(false)
+		if full := table.String(); virtualized != full {
+			t.Fatalf("expected virtualized table to render like the full table at offset %d:\n%s\n\ngot:\n%s", offset, full, virtualized)
+		}
+	}
+}
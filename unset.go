@@ -89,6 +89,12 @@ func (s Style) UnsetAlignVertical() Style {
 	return s
 }
 
+// UnsetAlignDecimal removes the decimal alignment style rule, if set.
+func (s Style) UnsetAlignDecimal() Style {
+	s.unset(alignDecimalKey)
+	return s
+}
+
 // UnsetPadding removes all padding style rules.
 func (s Style) UnsetPadding() Style {
 	s.unset(paddingLeftKey)